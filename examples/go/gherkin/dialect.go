@@ -0,0 +1,184 @@
+package gherkin
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed gherkin-languages.json
+var dialectData []byte
+
+// Dialect holds every keyword form a `.feature` file may use for one
+// natural language, as declared by a `# language: <code>` header (English,
+// "en", is assumed when a file has none).
+type Dialect struct {
+	Language string
+	Name     string
+	Native   string
+
+	Feature         []string
+	Rule            []string
+	Background      []string
+	Scenario        []string
+	ScenarioOutline []string
+	Examples        []string
+
+	Given []string
+	When  []string
+	Then  []string
+	And   []string
+	But   []string
+}
+
+// dialectJSON mirrors the on-disk shape of gherkin-languages.json.
+type dialectJSON struct {
+	Name            string   `json:"name"`
+	Native          string   `json:"native"`
+	Feature         []string `json:"feature"`
+	Background      []string `json:"background"`
+	Scenario        []string `json:"scenario"`
+	ScenarioOutline []string `json:"scenarioOutline"`
+	Examples        []string `json:"examples"`
+	Given           []string `json:"given"`
+	When            []string `json:"when"`
+	Then            []string `json:"then"`
+	And             []string `json:"and"`
+	But             []string `json:"but"`
+	Rule            []string `json:"rule"`
+}
+
+var (
+	dialectsOnce sync.Once
+	dialects     map[string]Dialect
+	dialectsErr  error
+)
+
+// Dialects returns every known Dialect, keyed by language code (e.g. "en",
+// "fr"), decoded from the embedded gherkin-languages.json.
+//
+// The embedded table covers a broad set of widely used languages (including
+// pt-BR, ru, ar, zh-CN, zh-TW, and ko), but is still a curated subset of the
+// full ~70-language canonical Cucumber gherkin-languages.json, not a mirror
+// of it; add entries to gherkin-languages.json to extend it further.
+func Dialects() map[string]Dialect {
+	dialectsOnce.Do(func() {
+		var raw map[string]dialectJSON
+		if err := json.Unmarshal(dialectData, &raw); err != nil {
+			dialectsErr = fmt.Errorf("gherkin: decode gherkin-languages.json: %w", err)
+			return
+		}
+		dialects = make(map[string]Dialect, len(raw))
+		for lang, d := range raw {
+			dialects[lang] = Dialect{
+				Language:        lang,
+				Name:            d.Name,
+				Native:          d.Native,
+				Feature:         d.Feature,
+				Rule:            d.Rule,
+				Background:      d.Background,
+				Scenario:        d.Scenario,
+				ScenarioOutline: d.ScenarioOutline,
+				Examples:        d.Examples,
+				Given:           d.Given,
+				When:            d.When,
+				Then:            d.Then,
+				And:             d.And,
+				But:             d.But,
+			}
+		}
+	})
+	if dialectsErr != nil {
+		panic(dialectsErr) // the embedded file is a build-time asset, not user input
+	}
+	return dialects
+}
+
+// DialectFor looks up the Dialect for a language code. An empty code is
+// treated as "en".
+func DialectFor(lang string) (Dialect, bool) {
+	if lang == "" {
+		lang = "en"
+	}
+	d, ok := Dialects()[lang]
+	return d, ok
+}
+
+// ValidateOptions configures [Engine.Validate].
+type ValidateOptions struct {
+	// Language overrides the dialect checked against, ignoring the source's
+	// own `# language:` header. Useful for asserting a file was written for
+	// a specific target dialect.
+	Language string
+}
+
+// Validate parses source and checks every step keyword against the dialect
+// declared by (or, via opts.Language, asserted for) the file, returning one
+// ParseError per mismatched keyword with its line/column.
+//
+// A syntax error during parsing is returned as the error value; keyword
+// mismatches are returned as the []ParseError result with a nil error.
+func (e *Engine) Validate(ctx context.Context, source string, opts ValidateOptions) ([]ParseError, error) {
+	doc, err := e.Parse(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Feature == nil {
+		return nil, nil
+	}
+
+	lang := opts.Language
+	if lang == "" {
+		lang = doc.Feature.Language
+	}
+	dialect, ok := DialectFor(lang)
+	if !ok {
+		return nil, fmt.Errorf("gherkin: unknown dialect %q", lang)
+	}
+
+	var mismatches []ParseError
+	walkDocument(doc, EventHandler{
+		OnStep: func(step Step) {
+			if err := checkStepKeyword(step, dialect); err != nil {
+				mismatches = append(mismatches, *err)
+			}
+		},
+	})
+	return mismatches, nil
+}
+
+// checkStepKeyword reports a ParseError if step's keyword isn't valid for
+// its KeywordType in dialect. Conjunctions (And/But) are checked against the
+// union of both, since the AST doesn't distinguish which one produced them.
+func checkStepKeyword(step Step, dialect Dialect) *ParseError {
+	var valid []string
+	switch step.KeywordType {
+	case KeywordTypeContext:
+		valid = dialect.Given
+	case KeywordTypeAction:
+		valid = dialect.When
+	case KeywordTypeOutcome:
+		valid = dialect.Then
+	case KeywordTypeConjunction:
+		valid = append(append([]string{}, dialect.And...), dialect.But...)
+	default:
+		return nil
+	}
+
+	keyword := strings.TrimSpace(step.Keyword)
+	for _, candidate := range valid {
+		if strings.TrimSpace(candidate) == keyword {
+			return nil
+		}
+	}
+
+	col := step.Location.Column
+	return &ParseError{
+		Message: fmt.Sprintf("keyword %q is not valid in dialect %q (%s)", step.Keyword, dialect.Language, dialect.Name),
+		Line:    step.Location.Line,
+		Column:  col,
+	}
+}
@@ -4,29 +4,58 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"sync/atomic"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
 )
 
+// DefaultMaxInstances is the size of the pooled-instance reservoir used by
+// [Engine.ParseBatch], unless overridden with [WithMaxInstances].
+const DefaultMaxInstances = 8
+
 // Engine manages a compiled WASM module and provides the Gherkin parsing API.
 //
 // Create an Engine once with [NewEngine], then call [Engine.Parse],
 // [Engine.Tokenize], or [Engine.Format] as many times as needed.
 // Each call creates a fresh WASM instance internally (the component model
-// does not support re-entrance).
+// does not support re-entrance). [Engine.ParseBatch] instead draws from a
+// bounded reservoir of instances it keeps warm across calls.
 type Engine struct {
 	runtime  wazero.Runtime
 	compiled wazero.CompiledModule
 	counter  atomic.Uint64
+
+	maxInstances int
+	pool         chan *instance
+	created      atomic.Int64
+
+	// poolMu guards closed and serializes it against releaseInstance's send
+	// to pool: Close must never close pool while a release is mid-send, and
+	// a release must never send to pool once Close has closed it.
+	poolMu sync.Mutex
+	closed bool
+}
+
+// EngineOption configures an [Engine] created with [NewEngine].
+type EngineOption func(*Engine)
+
+// WithMaxInstances bounds how many WASM instances [Engine.ParseBatch] keeps
+// in its reservoir. Defaults to [DefaultMaxInstances].
+func WithMaxInstances(n int) EngineOption {
+	return func(e *Engine) {
+		if n > 0 {
+			e.maxInstances = n
+		}
+	}
 }
 
 // NewEngine loads and compiles the core WASM module at wasmPath.
 //
 // The module is compiled once (with AOT compilation on amd64/arm64)
 // and reused across all subsequent calls.
-func NewEngine(ctx context.Context, wasmPath string) (*Engine, error) {
+func NewEngine(ctx context.Context, wasmPath string, opts ...EngineOption) (*Engine, error) {
 	wasmBytes, err := os.ReadFile(wasmPath)
 	if err != nil {
 		return nil, fmt.Errorf("read wasm module: %w", err)
@@ -40,11 +69,34 @@ func NewEngine(ctx context.Context, wasmPath string) (*Engine, error) {
 		return nil, fmt.Errorf("compile wasm module: %w", err)
 	}
 
-	return &Engine{runtime: r, compiled: compiled}, nil
+	e := &Engine{
+		runtime:      r,
+		compiled:     compiled,
+		maxInstances: DefaultMaxInstances,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.pool = make(chan *instance, e.maxInstances)
+
+	return e, nil
 }
 
-// Close releases all WASM resources.
+// Close releases all WASM resources, including any instances idling in the
+// [Engine.ParseBatch] reservoir. It's safe to call concurrently with
+// in-flight [Engine.ParseBatch]/[Engine.ParseStream]/[Engine.TokenizeStream]
+// workers: acquireInstance reports [ErrEngineClosed] once the pool channel
+// is closed, and releaseInstance (guarded by poolMu, same as Close) closes
+// its instance directly instead of racing to send on the closed channel.
 func (e *Engine) Close(ctx context.Context) error {
+	e.poolMu.Lock()
+	e.closed = true
+	close(e.pool)
+	e.poolMu.Unlock()
+
+	for inst := range e.pool {
+		inst.close(ctx)
+	}
 	return e.runtime.Close(ctx)
 }
 
@@ -0,0 +1,264 @@
+package gherkin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FormatPrettyOptions configures [Engine.FormatPretty].
+type FormatPrettyOptions struct {
+	// Indent is repeated once per nesting level. Defaults to two spaces.
+	Indent string
+	// TagsOnOwnLine puts each tag on its own line instead of packing all of
+	// a node's tags onto one line.
+	TagsOnOwnLine bool
+}
+
+func (o FormatPrettyOptions) indent() string {
+	if o.Indent == "" {
+		return "  "
+	}
+	return o.Indent
+}
+
+// FormatPretty parses source and re-emits it as Gherkin with configurable
+// indentation and tag placement, table columns padded to their widest
+// value, and step keywords capitalized consistently with the parsed
+// dialect — unlike [Engine.Format], which round-trips through the WASM
+// writer and preserves the source's own formatting quirks.
+func (e *Engine) FormatPretty(ctx context.Context, source string, opts FormatPrettyOptions) (string, error) {
+	doc, err := e.Parse(ctx, source)
+	if err != nil {
+		return "", err
+	}
+
+	dialect, _ := DialectFor("")
+	if doc.Feature != nil {
+		if d, ok := DialectFor(doc.Feature.Language); ok {
+			dialect = d
+		}
+	}
+
+	p := &prettyPrinter{opts: opts, dialect: dialect}
+	p.printDocument(doc)
+	return p.buf.String(), nil
+}
+
+// FormatJSON parses source and emits it as the Cucumber gherkin-document
+// JSON shape: a feature/background/scenario/rule/step tree with locations,
+// tags, docstrings, datatables, and examples, suitable for any tool that
+// consumes the official gherkin JSON stream.
+func (e *Engine) FormatJSON(ctx context.Context, source string) (string, error) {
+	doc, err := e.Parse(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshal gherkin document: %w", err)
+	}
+	return string(b), nil
+}
+
+// prettyPrinter accumulates pretty-printed Gherkin source for one Document.
+type prettyPrinter struct {
+	opts    FormatPrettyOptions
+	dialect Dialect
+	buf     strings.Builder
+}
+
+func (p *prettyPrinter) printDocument(doc *Document) {
+	if doc.Feature == nil {
+		return
+	}
+	p.printTags(doc.Feature.Tags, 0)
+	p.writeLine(0, "%s: %s", firstOr(p.dialect.Feature, "Feature"), doc.Feature.Name)
+	p.printDescription(doc.Feature.Description, 1)
+
+	for _, child := range doc.Feature.Children {
+		p.buf.WriteByte('\n')
+		switch {
+		case child.Background != nil:
+			p.printBackground(child.Background, 1)
+		case child.Scenario != nil:
+			p.printScenario(child.Scenario, 1)
+		case child.Rule != nil:
+			p.printRule(child.Rule)
+		}
+	}
+}
+
+func (p *prettyPrinter) printRule(rule *Rule) {
+	p.printTags(rule.Tags, 1)
+	p.writeLine(1, "%s: %s", firstOr(p.dialect.Rule, "Rule"), rule.Name)
+	p.printDescription(rule.Description, 2)
+	for _, child := range rule.Children {
+		p.buf.WriteByte('\n')
+		switch {
+		case child.Background != nil:
+			p.printBackground(child.Background, 2)
+		case child.Scenario != nil:
+			p.printScenario(child.Scenario, 2)
+		}
+	}
+}
+
+func (p *prettyPrinter) printBackground(bg *Background, level int) {
+	p.writeLine(level, "%s:", firstOr(p.dialect.Background, "Background"))
+	p.printDescription(bg.Description, level+1)
+	p.printSteps(bg.Steps, level+1)
+}
+
+func (p *prettyPrinter) printScenario(sc *Scenario, level int) {
+	p.printTags(sc.Tags, level)
+	keyword := firstOr(p.dialect.Scenario, "Scenario")
+	if sc.Kind == ScenarioKindScenarioOutline {
+		keyword = firstOr(p.dialect.ScenarioOutline, "Scenario Outline")
+	}
+	p.writeLine(level, "%s: %s", keyword, sc.Name)
+	p.printDescription(sc.Description, level+1)
+	p.printSteps(sc.Steps, level+1)
+
+	for _, ex := range sc.Examples {
+		p.buf.WriteByte('\n')
+		p.printTags(ex.Tags, level+1)
+		p.writeLine(level+1, "%s:%s", firstOr(p.dialect.Examples, "Examples"), optionalName(ex.Name))
+		p.printDescription(ex.Description, level+2)
+		var rows []TableRow
+		if ex.TableHeader != nil {
+			rows = append(rows, *ex.TableHeader)
+		}
+		rows = append(rows, ex.TableBody...)
+		p.printTable(rows, level+2)
+	}
+}
+
+func (p *prettyPrinter) printSteps(steps []Step, level int) {
+	for _, step := range steps {
+		keyword := p.canonicalKeyword(step)
+		p.writeLine(level, "%s%s", keyword, step.Text)
+		if step.Argument == nil {
+			continue
+		}
+		switch {
+		case step.Argument.DocString != nil:
+			p.printDocString(step.Argument.DocString, level+1)
+		case step.Argument.DataTable != nil:
+			p.printTable(step.Argument.DataTable.Rows, level+1)
+		}
+	}
+}
+
+// canonicalKeyword picks the dialect's canonical spelling for a step's
+// KeywordType, giving every step in the document consistent capitalization
+// regardless of which synonym the source used. Conjunctions (And/But) keep
+// their original text, since KeywordType alone can't say which of the two
+// dialect lists produced them.
+func (p *prettyPrinter) canonicalKeyword(step Step) string {
+	var candidates []string
+	switch step.KeywordType {
+	case KeywordTypeContext:
+		candidates = p.dialect.Given
+	case KeywordTypeAction:
+		candidates = p.dialect.When
+	case KeywordTypeOutcome:
+		candidates = p.dialect.Then
+	default:
+		return step.Keyword
+	}
+	for _, c := range candidates {
+		if c != "* " {
+			return c
+		}
+	}
+	return step.Keyword
+}
+
+func (p *prettyPrinter) printDocString(ds *DocString, level int) {
+	delim := ds.Delimiter
+	if delim == "" {
+		delim = `"""`
+	}
+	p.writeLine(level, "%s%s", delim, ds.MediaType)
+	for _, line := range strings.Split(ds.Content, "\n") {
+		p.writeLine(level, "%s", line)
+	}
+	p.writeLine(level, "%s", delim)
+}
+
+// printTable renders rows with every column padded to its widest cell,
+// including the header row when one is present.
+func (p *prettyPrinter) printTable(rows []TableRow, level int) {
+	if len(rows) == 0 {
+		return
+	}
+	widths := make([]int, len(rows[0].Cells))
+	for _, row := range rows {
+		for i, cell := range row.Cells {
+			if i < len(widths) && len(cell.Value) > widths[i] {
+				widths[i] = len(cell.Value)
+			}
+		}
+	}
+	for _, row := range rows {
+		var b strings.Builder
+		b.WriteString("|")
+		for i, cell := range row.Cells {
+			w := 0
+			if i < len(widths) {
+				w = widths[i]
+			}
+			fmt.Fprintf(&b, " %-*s |", w, cell.Value)
+		}
+		p.writeLine(level, "%s", b.String())
+	}
+}
+
+func (p *prettyPrinter) printTags(tags []Tag, level int) {
+	if len(tags) == 0 {
+		return
+	}
+	if p.opts.TagsOnOwnLine {
+		for _, tag := range tags {
+			p.writeLine(level, "%s", tag.Name)
+		}
+		return
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	p.writeLine(level, "%s", strings.Join(names, " "))
+}
+
+func (p *prettyPrinter) printDescription(desc string, level int) {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return
+	}
+	for _, line := range strings.Split(desc, "\n") {
+		p.writeLine(level, "%s", line)
+	}
+}
+
+func (p *prettyPrinter) writeLine(level int, format string, args ...any) {
+	p.buf.WriteString(strings.Repeat(p.opts.indent(), level))
+	fmt.Fprintf(&p.buf, format, args...)
+	p.buf.WriteByte('\n')
+}
+
+func firstOr(candidates []string, fallback string) string {
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return fallback
+}
+
+func optionalName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return " " + name
+}
@@ -0,0 +1,83 @@
+package gherkin
+
+import (
+	"crypto/rand"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync/atomic"
+)
+
+// IDGenerator produces unique IDs for compiled pickles and pickle steps.
+// nodeID is the originating AST node's own ID (a [Scenario], a Scenario
+// Outline's per-row [ConcreteScenario], or a [Step], whose ID is joined with
+// its Examples row's ID by ";" for an outline step) — implementations that
+// want IDs stable across runs derive them from it instead of call order.
+//
+// Implementations must be safe for concurrent use, since a single generator
+// may be shared across concurrent [Compile] calls.
+type IDGenerator interface {
+	NewID(nodeID string) string
+}
+
+// incrementingIDGenerator is the default IDGenerator: an atomic counter
+// formatted as a decimal string.
+type incrementingIDGenerator struct {
+	counter atomic.Uint64
+}
+
+// NewIncrementingIDGenerator returns an IDGenerator that yields "1", "2",
+// "3", and so on, in call order. This is the default generator used by
+// [Compile] when none is supplied.
+func NewIncrementingIDGenerator() IDGenerator {
+	return &incrementingIDGenerator{}
+}
+
+func (g *incrementingIDGenerator) NewID(nodeID string) string {
+	return strconv.FormatUint(g.counter.Add(1), 10)
+}
+
+// uuidIDGenerator is an IDGenerator that yields random (version 4) UUIDs.
+type uuidIDGenerator struct{}
+
+// NewUUIDIDGenerator returns an IDGenerator that yields random UUIDs,
+// matching the default ID scheme used by most other Cucumber implementations.
+func NewUUIDIDGenerator() IDGenerator {
+	return &uuidIDGenerator{}
+}
+
+// hashIDGenerator derives IDs from a hash of a fixed seed (typically a
+// Source's URI) combined with the originating AST node's own ID, so IDs for
+// a given file are stable across repeated runs and across reordering,
+// insertion, or deletion of unrelated scenarios in the same file — unlike a
+// per-call sequence counter, which would reassign every later pickle's ID
+// whenever an earlier scenario in the file changed.
+type hashIDGenerator struct {
+	seed uint32
+}
+
+// NewHashIDGenerator returns an IDGenerator that derives IDs from a hash of
+// seed combined with each pickle's or step's originating AST node ID.
+func NewHashIDGenerator(seed string) IDGenerator {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seed))
+	return &hashIDGenerator{seed: h.Sum32()}
+}
+
+func (g *hashIDGenerator) NewID(nodeID string) string {
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%08x", g.seed)
+	_, _ = h.Write([]byte{0}) // separator, so "ab"+"c" and "a"+"bc" don't collide
+	_, _ = h.Write([]byte(nodeID))
+	return fmt.Sprintf("%08x-%08x", g.seed, h.Sum32())
+}
+
+func (g *uuidIDGenerator) NewID(nodeID string) string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("gherkin: read random bytes for UUID: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,231 @@
+package gherkin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Source is a single named unit of Gherkin source text, carried in an
+// Envelope's Source field and passed to [Engine.Messages].
+type Source struct {
+	URI       string `json:"uri"`
+	Data      string `json:"data"`
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+// GherkinDocumentMessage is the parsed form of a Source, carried in an
+// Envelope's GherkinDocument field.
+type GherkinDocumentMessage struct {
+	URI      string    `json:"uri"`
+	Feature  *Feature  `json:"feature,omitempty"`
+	Comments []Comment `json:"comments"`
+}
+
+// ParseErrorMessage reports that a Source failed to parse, carried in an
+// Envelope's ParseError field.
+type ParseErrorMessage struct {
+	URI    string      `json:"uri"`
+	Errors ParseErrors `json:"errors"`
+}
+
+// Attachment is a piece of evidence attached to a running test step (e.g. a
+// screenshot or log excerpt). This package never produces attachments itself
+// — the field exists so Envelope mirrors the full cross-language Cucumber
+// Messages schema, letting a test runner built on top of gherkin multiplex
+// its own attachment messages onto the same NDJSON stream.
+type Attachment struct {
+	URI       string `json:"uri,omitempty"`
+	Body      string `json:"body"`
+	MediaType string `json:"mediaType"`
+}
+
+// Meta describes the producer of a Cucumber Messages stream.
+type Meta struct {
+	ProtocolVersion string `json:"protocolVersion"`
+	Implementation  string `json:"implementation"`
+}
+
+// Envelope is a single message in a Cucumber Messages NDJSON stream: a
+// tagged union where exactly one field is non-nil, mirroring the
+// cross-language Cucumber Messages schema.
+type Envelope struct {
+	Source          *Source                 `json:"source,omitempty"`
+	GherkinDocument *GherkinDocumentMessage `json:"gherkinDocument,omitempty"`
+	Pickle          *Pickle                 `json:"pickle,omitempty"`
+	ParseError      *ParseErrorMessage      `json:"parseError,omitempty"`
+	Attachment      *Attachment             `json:"attachment,omitempty"`
+	Meta            *Meta                   `json:"meta,omitempty"`
+}
+
+// MessagesOptions configures [Engine.Messages].
+type MessagesOptions struct {
+	// IncludeSource emits a Source envelope for each input Source.
+	IncludeSource bool
+	// IncludeGherkinDocument emits a GherkinDocument envelope for each
+	// successfully parsed Source.
+	IncludeGherkinDocument bool
+	// IncludePickles emits Pickle envelopes for each successfully parsed
+	// Source, compiled via [Compile].
+	IncludePickles bool
+	// NewID generates IDs for compiled Pickles. If nil, Messages uses
+	// [NewIncrementingIDGenerator].
+	NewID IDGenerator
+}
+
+// Messages parses each of sources and streams the requested Cucumber
+// Messages envelopes on the returned channel, in source order. A Source that
+// fails to parse yields a ParseError envelope instead of GherkinDocument/
+// Pickle envelopes, so one bad file in a batch still lets the rest come
+// through.
+//
+// The channel is closed once every source has been processed or ctx is
+// canceled.
+func (e *Engine) Messages(ctx context.Context, sources []Source, opts MessagesOptions) (<-chan Envelope, error) {
+	gen := opts.NewID
+	if gen == nil {
+		gen = NewIncrementingIDGenerator()
+	}
+
+	ch := make(chan Envelope)
+	go func() {
+		defer close(ch)
+		for _, src := range sources {
+			if ctx.Err() != nil {
+				return
+			}
+			if !e.emitEnvelopes(ctx, ch, src, opts, gen) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// emitEnvelopes produces every envelope for one Source, returning false if
+// ctx was canceled while sending.
+func (e *Engine) emitEnvelopes(ctx context.Context, ch chan<- Envelope, src Source, opts MessagesOptions, gen IDGenerator) bool {
+	send := func(env Envelope) bool {
+		select {
+		case ch <- env:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if opts.IncludeSource {
+		s := src
+		if !send(Envelope{Source: &s}) {
+			return false
+		}
+	}
+
+	if !opts.IncludeGherkinDocument && !opts.IncludePickles {
+		return true
+	}
+
+	doc, err := e.Parse(ctx, src.Data)
+	if err != nil {
+		var perrs ParseErrors
+		switch typed := err.(type) {
+		case ParseErrors:
+			perrs = typed
+		case *ParseError:
+			perrs = ParseErrors{*typed}
+		default:
+			perrs = ParseErrors{{Message: err.Error()}}
+		}
+		return send(Envelope{ParseError: &ParseErrorMessage{URI: src.URI, Errors: perrs}})
+	}
+
+	if opts.IncludeGherkinDocument {
+		msg := &GherkinDocumentMessage{URI: src.URI, Comments: doc.Comments}
+		if doc.Feature != nil {
+			msg.Feature = doc.Feature
+		}
+		if !send(Envelope{GherkinDocument: msg}) {
+			return false
+		}
+	}
+
+	if opts.IncludePickles {
+		pickles, err := Compile(doc, src.URI, CompileOptions{IDGenerator: gen})
+		if err != nil {
+			return send(Envelope{ParseError: &ParseErrorMessage{URI: src.URI, Errors: ParseErrors{{Message: err.Error()}}}})
+		}
+		for i := range pickles {
+			if !send(Envelope{Pickle: &pickles[i]}) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Message is an alias for Envelope. It's the vocabulary [Engine.MessagesForSource]
+// and [Engine.MessagesNDJSON] use for callers who think in terms of "the
+// Cucumber Messages for this one file" rather than a general envelope
+// stream.
+type Message = Envelope
+
+// MessagesForSource parses source and collects its Cucumber Messages — a
+// Source message, a GherkinDocument message, and one Pickle message per
+// concrete scenario — into a single slice.
+//
+// It's built on top of the streaming [Engine.Messages] with a single-element
+// sources slice and every Include* option set, for callers who just want one
+// file's messages back as a slice rather than reading a channel. Pickle IDs
+// are derived from a hash of uri and each pickle's originating AST node, so
+// they stay stable across repeated runs and across scenario reordering in
+// the same file.
+func (e *Engine) MessagesForSource(ctx context.Context, source, uri string) ([]Message, error) {
+	ch, err := e.Messages(ctx, []Source{{URI: uri, Data: source}}, MessagesOptions{
+		IncludeSource:          true,
+		IncludeGherkinDocument: true,
+		IncludePickles:         true,
+		NewID:                  NewHashIDGenerator(uri),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var messages []Message
+	for env := range ch {
+		messages = append(messages, env)
+	}
+	return messages, nil
+}
+
+// MessagesNDJSON parses source and writes its Cucumber Messages — a Source
+// message, a GherkinDocument message, and one Pickle message per concrete
+// scenario — to w as newline-delimited JSON, so this module can act as a
+// drop-in gherkin producer for any Cucumber-compatible runner that consumes
+// messages on stdin.
+func (e *Engine) MessagesNDJSON(ctx context.Context, source, uri string, w io.Writer) error {
+	ch, err := e.Messages(ctx, []Source{{URI: uri, Data: source}}, MessagesOptions{
+		IncludeSource:          true,
+		IncludeGherkinDocument: true,
+		IncludePickles:         true,
+		NewID:                  NewHashIDGenerator(uri),
+	})
+	if err != nil {
+		return err
+	}
+	return WriteNDJSON(w, ch)
+}
+
+// WriteNDJSON serializes each Envelope received from ch as one JSON object
+// per line, in the order received.
+func WriteNDJSON(w io.Writer, ch <-chan Envelope) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for env := range ch {
+		if err := enc.Encode(env); err != nil {
+			return fmt.Errorf("encode envelope: %w", err)
+		}
+	}
+	return bw.Flush()
+}
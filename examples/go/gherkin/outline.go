@@ -0,0 +1,169 @@
+package gherkin
+
+import "fmt"
+
+// ConcreteScenario is a Scenario Outline plus one Examples row, flattened
+// into something that runs just like a plain Scenario: every `<param>`
+// placeholder in step text, DocString content, and DataTable cells has been
+// substituted from the row.
+type ConcreteScenario struct {
+	ID          string
+	Keyword     string
+	Name        string
+	Description string
+	Location    Location
+	Tags        []Tag
+	Steps       []Step
+
+	// Row is the Examples row this ConcreteScenario was generated from, so
+	// callers that need to trace back to the source AST (e.g. the pickle
+	// compiler) can still get at its ID.
+	Row *TableRow
+}
+
+// Expand turns a Scenario into a flat list of ConcreteScenarios: itself,
+// unchanged, if it's a plain Scenario; one per Examples row — with
+// `<param>` placeholders in step text, DocString content, and DataTable
+// cells substituted from that row — if it's a Scenario Outline.
+//
+// Each generated ID is "<outlineID>;<exampleName>;<rowIndex>", and its Tags
+// are the outline's own tags plus the owning Examples block's tags.
+func (sc *Scenario) Expand() []ConcreteScenario {
+	if sc.Kind != ScenarioKindScenarioOutline {
+		return []ConcreteScenario{{
+			ID:          sc.ID,
+			Keyword:     sc.Keyword,
+			Name:        sc.Name,
+			Description: sc.Description,
+			Location:    sc.Location,
+			Tags:        sc.Tags,
+			Steps:       sc.Steps,
+		}}
+	}
+
+	var out []ConcreteScenario
+	for _, ex := range sc.Examples {
+		if ex.TableHeader == nil {
+			continue
+		}
+		headers := make([]string, len(ex.TableHeader.Cells))
+		for i, cell := range ex.TableHeader.Cells {
+			headers[i] = cell.Value
+		}
+		tags := mergeTags(sc.Tags, ex.Tags)
+
+		for rowIdx, row := range ex.TableBody {
+			row := row
+			params := make(map[string]string, len(headers))
+			for i, header := range headers {
+				if i < len(row.Cells) {
+					params[header] = row.Cells[i].Value
+				}
+			}
+
+			out = append(out, ConcreteScenario{
+				ID:          fmt.Sprintf("%s;%s;%d", sc.ID, ex.Name, rowIdx),
+				Keyword:     sc.Keyword,
+				Name:        substitute(sc.Name, params),
+				Description: sc.Description,
+				Location:    row.Location,
+				Tags:        tags,
+				Steps:       substituteSteps(sc.Steps, params),
+				Row:         &row,
+			})
+		}
+	}
+	return out
+}
+
+// ExpandOutlines returns a copy of d with every Scenario Outline replaced by
+// its Expand()-ed concrete Scenarios, so the whole Document can be walked or
+// re-formatted as if it had no outlines at all.
+func (d *Document) ExpandOutlines() *Document {
+	if d == nil || d.Feature == nil {
+		return d
+	}
+	return &Document{Feature: expandFeatureOutlines(d.Feature), Comments: d.Comments}
+}
+
+func expandFeatureOutlines(f *Feature) *Feature {
+	nf := *f
+	nf.Children = nil
+	for _, child := range f.Children {
+		switch {
+		case child.Scenario != nil:
+			for _, cs := range child.Scenario.Expand() {
+				nf.Children = append(nf.Children, FeatureChild{Scenario: concreteToScenario(cs)})
+			}
+		case child.Rule != nil:
+			nf.Children = append(nf.Children, FeatureChild{Rule: expandRuleOutlines(child.Rule)})
+		default:
+			nf.Children = append(nf.Children, child)
+		}
+	}
+	return &nf
+}
+
+func expandRuleOutlines(r *Rule) *Rule {
+	nr := *r
+	nr.Children = nil
+	for _, child := range r.Children {
+		if child.Scenario == nil {
+			nr.Children = append(nr.Children, child)
+			continue
+		}
+		for _, cs := range child.Scenario.Expand() {
+			nr.Children = append(nr.Children, RuleChild{Scenario: concreteToScenario(cs)})
+		}
+	}
+	return &nr
+}
+
+func concreteToScenario(cs ConcreteScenario) *Scenario {
+	return &Scenario{
+		Location:    cs.Location,
+		Tags:        cs.Tags,
+		Kind:        ScenarioKindScenario,
+		Keyword:     cs.Keyword,
+		Name:        cs.Name,
+		Description: cs.Description,
+		ID:          cs.ID,
+		Steps:       cs.Steps,
+	}
+}
+
+// substituteSteps returns a copy of steps with `<param>` placeholders
+// substituted in step text, DocString content, and DataTable cells.
+func substituteSteps(steps []Step, params map[string]string) []Step {
+	out := make([]Step, len(steps))
+	for i, step := range steps {
+		ns := step
+		ns.Text = substitute(step.Text, params)
+		if step.Argument != nil {
+			arg := *step.Argument
+			if ds := step.Argument.DocString; ds != nil {
+				substituted := *ds
+				substituted.Content = substitute(ds.Content, params)
+				arg.DocString = &substituted
+			}
+			if dt := step.Argument.DataTable; dt != nil {
+				arg.DataTable = substituteDataTable(dt, params)
+			}
+			ns.Argument = &arg
+		}
+		out[i] = ns
+	}
+	return out
+}
+
+func substituteDataTable(dt *DataTable, params map[string]string) *DataTable {
+	ndt := &DataTable{Location: dt.Location, Rows: make([]TableRow, len(dt.Rows))}
+	for i, row := range dt.Rows {
+		nrow := TableRow{Location: row.Location, ID: row.ID, Cells: make([]TableCell, len(row.Cells))}
+		for j, cell := range row.Cells {
+			nrow.Cells[j] = TableCell{Location: cell.Location, Value: substitute(cell.Value, params)}
+		}
+		ndt.Rows[i] = nrow
+	}
+	return ndt
+}
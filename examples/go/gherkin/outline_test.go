@@ -0,0 +1,144 @@
+package gherkin
+
+import "testing"
+
+func TestScenarioExpandPlainScenario(t *testing.T) {
+	sc := &Scenario{
+		ID:    "sc1",
+		Kind:  ScenarioKindScenario,
+		Name:  "plain",
+		Tags:  []Tag{tag("@a")},
+		Steps: []Step{step(KeywordTypeAction, "do it", nil)},
+	}
+
+	got := sc.Expand()
+	if len(got) != 1 {
+		t.Fatalf("got %d concrete scenarios, want 1", len(got))
+	}
+	if got[0].ID != "sc1" || got[0].Name != "plain" || got[0].Row != nil {
+		t.Errorf("Expand() = %+v", got[0])
+	}
+}
+
+func TestScenarioExpandOutlineOneRowPerExample(t *testing.T) {
+	sc := &Scenario{
+		ID:   "outline1",
+		Kind: ScenarioKindScenarioOutline,
+		Name: "adding <a> and <b>",
+		Tags: []Tag{tag("@outline")},
+		Steps: []Step{
+			step(KeywordTypeContext, "I have <a> and <b>", nil),
+		},
+		Examples: []Examples{{
+			Name: "positive",
+			Tags: []Tag{tag("@positive")},
+			TableHeader: &TableRow{Cells: []TableCell{{Value: "a"}, {Value: "b"}}},
+			TableBody: []TableRow{
+				{ID: "row0", Cells: []TableCell{{Value: "1"}, {Value: "2"}}},
+				{ID: "row1", Cells: []TableCell{{Value: "2"}, {Value: "2"}}},
+			},
+		}},
+	}
+
+	got := sc.Expand()
+	if len(got) != 2 {
+		t.Fatalf("got %d concrete scenarios, want 2", len(got))
+	}
+
+	wantIDs := []string{"outline1;positive;0", "outline1;positive;1"}
+	wantTexts := []string{"I have 1 and 2", "I have 2 and 2"}
+	for i, cs := range got {
+		if cs.ID != wantIDs[i] {
+			t.Errorf("concreteScenario[%d].ID = %q, want %q", i, cs.ID, wantIDs[i])
+		}
+		if cs.Steps[0].Text != wantTexts[i] {
+			t.Errorf("concreteScenario[%d].Steps[0].Text = %q, want %q", i, cs.Steps[0].Text, wantTexts[i])
+		}
+		if !stringsEqual(tagNames(cs.Tags), []string{"@outline", "@positive"}) {
+			t.Errorf("concreteScenario[%d].Tags = %v", i, cs.Tags)
+		}
+		if cs.Row == nil {
+			t.Errorf("concreteScenario[%d].Row = nil, want the originating row", i)
+		}
+	}
+}
+
+func TestScenarioExpandOutlineSkipsExamplesWithoutHeader(t *testing.T) {
+	sc := &Scenario{
+		ID:   "outline1",
+		Kind: ScenarioKindScenarioOutline,
+		Name: "no header",
+		Examples: []Examples{{
+			TableBody: []TableRow{{ID: "row0", Cells: []TableCell{{Value: "1"}}}},
+		}},
+	}
+
+	if got := sc.Expand(); len(got) != 0 {
+		t.Fatalf("got %d concrete scenarios, want 0", len(got))
+	}
+}
+
+func TestDocumentExpandOutlinesReplacesOutlinesThroughoutTree(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Children: []FeatureChild{
+			{Scenario: &Scenario{ID: "plain1", Kind: ScenarioKindScenario, Name: "plain"}},
+			{Scenario: &Scenario{
+				ID:   "outline1",
+				Kind: ScenarioKindScenarioOutline,
+				Name: "outline <n>",
+				Examples: []Examples{{
+					TableHeader: &TableRow{Cells: []TableCell{{Value: "n"}}},
+					TableBody:   []TableRow{{ID: "row0", Cells: []TableCell{{Value: "1"}}}},
+				}},
+			}},
+			{Rule: &Rule{Children: []RuleChild{
+				{Scenario: &Scenario{
+					ID:   "ruleoutline1",
+					Kind: ScenarioKindScenarioOutline,
+					Name: "rule outline <n>",
+					Examples: []Examples{{
+						TableHeader: &TableRow{Cells: []TableCell{{Value: "n"}}},
+						TableBody:   []TableRow{{ID: "row0", Cells: []TableCell{{Value: "2"}}}},
+					}},
+				}},
+			}}},
+		},
+	}}
+
+	got := doc.ExpandOutlines()
+
+	feature := got.Feature
+	if len(feature.Children) != 3 {
+		t.Fatalf("got %d feature children, want 3", len(feature.Children))
+	}
+	if feature.Children[0].Scenario.Name != "plain" {
+		t.Errorf("feature.Children[0] = %+v, want the untouched plain scenario", feature.Children[0].Scenario)
+	}
+	outlineScenario := feature.Children[1].Scenario
+	if outlineScenario.Kind != ScenarioKindScenario || outlineScenario.Name != "outline 1" {
+		t.Errorf("feature.Children[1] = %+v, want an expanded plain scenario named %q", outlineScenario, "outline 1")
+	}
+
+	rule := feature.Children[2].Rule
+	ruleScenario := rule.Children[0].Scenario
+	if ruleScenario.Kind != ScenarioKindScenario || ruleScenario.Name != "rule outline 2" {
+		t.Errorf("rule.Children[0] = %+v, want an expanded plain scenario named %q", ruleScenario, "rule outline 2")
+	}
+
+	// The original document must be untouched.
+	if doc.Feature.Children[1].Scenario.Kind != ScenarioKindScenarioOutline {
+		t.Error("ExpandOutlines() mutated the original Document")
+	}
+}
+
+func TestDocumentExpandOutlinesNilDocument(t *testing.T) {
+	var doc *Document
+	if got := doc.ExpandOutlines(); got != nil {
+		t.Errorf("ExpandOutlines() on nil Document = %+v, want nil", got)
+	}
+
+	empty := &Document{}
+	if got := empty.ExpandOutlines(); got != empty {
+		t.Errorf("ExpandOutlines() on a Document with no Feature = %+v, want the same Document unchanged", got)
+	}
+}
@@ -0,0 +1,339 @@
+package gherkin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EventHandler receives callbacks as a [Parser] walks a parsed Document.
+// Any field left nil is simply not called. Handlers are invoked in source
+// order: a Feature's Background (if any) fires before its Scenarios, a
+// Scenario Outline fires one OnExamplesRow per expanded row before its
+// OnScenarioEnd, and OnComment fires interleaved by line with every other
+// callback rather than only after the whole Feature has been walked.
+type EventHandler struct {
+	OnFeature       func(*Feature)
+	OnBackground    func(*Background)
+	OnScenarioStart func(*Scenario)
+	OnScenarioEnd   func(*Scenario)
+	OnStep          func(Step)
+	OnExamplesRow   func(examples *Examples, row TableRow, index int)
+	OnComment       func(Comment)
+	OnError         func(error)
+}
+
+// DefaultChunkSize is the buffer size [Parser] uses when reading from an
+// io.Reader, unless overridden with [WithChunkSize].
+const DefaultChunkSize = 64 * 1024
+
+// DefaultParseFSConcurrency is the number of files [Parser.ParseFS] parses
+// concurrently, unless overridden with [WithConcurrency].
+const DefaultParseFSConcurrency = 4
+
+// Parser parses Gherkin source from an io.Reader (or a filesystem tree) and
+// reports the result through an [EventHandler], instead of requiring the
+// caller to already have the whole source in a Go string.
+//
+// Today it works by buffering the reader in chunkSize increments, handing
+// the full buffer to the underlying [Engine], and walking the resulting AST
+// once to fire events — the WASM parser has no incremental entry point. The
+// EventHandler-based API is deliberately decoupled from that detail so a
+// future native-Go or Component-Model backend can parse truly incrementally
+// without changing callers.
+type Parser struct {
+	engine      *Engine
+	chunkSize   int
+	concurrency int
+}
+
+// ParserOption configures a [Parser] created with [NewParser].
+type ParserOption func(*Parser)
+
+// WithChunkSize sets the buffer size Parser.Parse uses when reading from an
+// io.Reader.
+func WithChunkSize(n int) ParserOption {
+	return func(p *Parser) {
+		if n > 0 {
+			p.chunkSize = n
+		}
+	}
+}
+
+// WithConcurrency sets how many files Parser.ParseFS parses at once.
+func WithConcurrency(n int) ParserOption {
+	return func(p *Parser) {
+		if n > 0 {
+			p.concurrency = n
+		}
+	}
+}
+
+// NewParser creates a Parser backed by engine.
+func NewParser(engine *Engine, opts ...ParserOption) *Parser {
+	p := &Parser{
+		engine:      engine,
+		chunkSize:   DefaultChunkSize,
+		concurrency: DefaultParseFSConcurrency,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse reads all of r, parses it, and reports the result through handler.
+// A parse error is reported via handler.OnError rather than returned,
+// consistent with ParseFS's per-file error handling; Parse only returns an
+// error for failures reading from r itself.
+func (p *Parser) Parse(ctx context.Context, r io.Reader, handler EventHandler) error {
+	var buf bytes.Buffer
+	chunk := make([]byte, p.chunkSize)
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read source: %w", err)
+		}
+	}
+
+	doc, err := p.engine.Parse(ctx, buf.String())
+	if err != nil {
+		if handler.OnError != nil {
+			handler.OnError(err)
+		}
+		return nil
+	}
+
+	walkDocument(doc, handler)
+	return nil
+}
+
+// ParseFS parses every file matching glob under fsys, reporting each one's
+// result through handler. Files are parsed with up to p.concurrency workers
+// at a time; handler callbacks are serialized so a handler need not be
+// concurrency-safe itself, but the order in which different files' events
+// interleave is not guaranteed.
+func (p *Parser) ParseFS(ctx context.Context, fsys fs.FS, glob string, handler EventHandler) error {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ok, err := matchGlob(glob, path); err != nil {
+			return err
+		} else if ok {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walk %q: %w", glob, err)
+	}
+
+	var (
+		mu       sync.Mutex
+		sem      = make(chan struct{}, p.concurrency)
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	for _, path := range paths {
+		path := path
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			data, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("read %q: %w", path, err)
+				}
+				mu.Unlock()
+				return
+			}
+
+			doc, err := p.engine.Parse(ctx, string(data))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if handler.OnError != nil {
+					handler.OnError(fmt.Errorf("%s: %w", path, err))
+				}
+				return
+			}
+			walkDocument(doc, handler)
+		}()
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return nil
+}
+
+// matchGlob matches name against pattern, extending [path.Match] with
+// support for a single "**" segment meaning "zero or more path segments" —
+// enough to express the common "features/**/*.feature" case, which plain
+// path.Match (and fs.Glob) can't express since "*" never crosses a "/".
+//
+// path.Match, not filepath.Match, is deliberate: fsys paths are always
+// "/"-separated regardless of host OS, but filepath.Match's separator
+// handling follows the host OS (backslash on Windows), which would break
+// "**" segment splitting there.
+func matchGlob(pattern, name string) (bool, error) {
+	if !strings.Contains(pattern, "**") {
+		return path.Match(pattern, name)
+	}
+
+	parts := strings.SplitN(pattern, "**", 2)
+	prefix := strings.TrimSuffix(parts[0], "/")
+	suffix := strings.TrimPrefix(parts[1], "/")
+
+	if prefix != "" && !strings.HasPrefix(name, prefix) {
+		return false, nil
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(name, prefix), "/")
+
+	if suffix == "" {
+		return true, nil
+	}
+	segments := strings.Split(rest, "/")
+	for i := range segments {
+		candidate := strings.Join(segments[i:], "/")
+		if ok, err := path.Match(suffix, candidate); err != nil {
+			return false, err
+		} else if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// commentWalker interleaves handler.OnComment calls with the rest of
+// walkDocument's traversal, by source line, instead of firing every comment
+// only after the whole Feature has been walked.
+type commentWalker struct {
+	comments []Comment
+	next     int
+	handler  EventHandler
+}
+
+// flushBefore fires OnComment for every remaining comment at or before
+// line, in source order.
+func (cw *commentWalker) flushBefore(line int32) {
+	if cw.handler.OnComment == nil {
+		return
+	}
+	for cw.next < len(cw.comments) && cw.comments[cw.next].Location.Line <= line {
+		cw.handler.OnComment(cw.comments[cw.next])
+		cw.next++
+	}
+}
+
+// flushRest fires OnComment for every comment left after the traversal
+// (e.g. one trailing a Feature with no more nodes after it).
+func (cw *commentWalker) flushRest() {
+	cw.flushBefore(1<<31 - 1) // math.MaxInt32
+}
+
+// walkDocument fires handler callbacks for doc in source order, including
+// OnComment calls interleaved by line with every other callback.
+func walkDocument(doc *Document, handler EventHandler) {
+	comments := append([]Comment(nil), doc.Comments...)
+	sort.Slice(comments, func(i, j int) bool { return comments[i].Location.Line < comments[j].Location.Line })
+	cw := &commentWalker{comments: comments, handler: handler}
+
+	if doc.Feature != nil {
+		cw.flushBefore(doc.Feature.Location.Line)
+		if handler.OnFeature != nil {
+			handler.OnFeature(doc.Feature)
+		}
+		for _, child := range doc.Feature.Children {
+			walkFeatureChild(child, handler, cw)
+		}
+	}
+	cw.flushRest()
+}
+
+func walkFeatureChild(child FeatureChild, handler EventHandler, cw *commentWalker) {
+	switch {
+	case child.Background != nil:
+		walkBackground(child.Background, handler, cw)
+	case child.Scenario != nil:
+		walkScenario(child.Scenario, handler, cw)
+	case child.Rule != nil:
+		cw.flushBefore(child.Rule.Location.Line)
+		for _, rc := range child.Rule.Children {
+			switch {
+			case rc.Background != nil:
+				walkBackground(rc.Background, handler, cw)
+			case rc.Scenario != nil:
+				walkScenario(rc.Scenario, handler, cw)
+			}
+		}
+	}
+}
+
+func walkBackground(bg *Background, handler EventHandler, cw *commentWalker) {
+	cw.flushBefore(bg.Location.Line)
+	if handler.OnBackground != nil {
+		handler.OnBackground(bg)
+	}
+	walkSteps(bg.Steps, handler, cw)
+}
+
+func walkScenario(sc *Scenario, handler EventHandler, cw *commentWalker) {
+	cw.flushBefore(sc.Location.Line)
+	if handler.OnScenarioStart != nil {
+		handler.OnScenarioStart(sc)
+	}
+	walkSteps(sc.Steps, handler, cw)
+	for i := range sc.Examples {
+		ex := &sc.Examples[i]
+		cw.flushBefore(ex.Location.Line)
+		for rowIdx, row := range ex.TableBody {
+			cw.flushBefore(row.Location.Line)
+			if handler.OnExamplesRow != nil {
+				handler.OnExamplesRow(ex, row, rowIdx)
+			}
+		}
+	}
+	if handler.OnScenarioEnd != nil {
+		handler.OnScenarioEnd(sc)
+	}
+}
+
+func walkSteps(steps []Step, handler EventHandler, cw *commentWalker) {
+	for _, step := range steps {
+		cw.flushBefore(step.Location.Line)
+		if handler.OnStep != nil {
+			handler.OnStep(step)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package gherkin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Pickle is the flattened, execution-ready form of a single concrete
+// scenario: either a plain Scenario, or one Examples row of a Scenario
+// Outline with its `<param>` placeholders substituted.
+//
+// Every Cucumber runner consumes Pickles rather than the raw AST, since a
+// Scenario Outline with N examples rows must run as N independent tests.
+type Pickle struct {
+	ID         string       `json:"id"`
+	URI        string       `json:"uri"`
+	Name       string       `json:"name"`
+	Language   string       `json:"language"`
+	Tags       []string     `json:"tags"`
+	AstNodeIDs []string     `json:"astNodeIds"`
+	Steps      []PickleStep `json:"steps"`
+}
+
+// PickleStepType classifies a PickleStep the way a runner needs to treat it:
+// setup, action, or assertion. Conjunctions (And/But) resolve to the type of
+// the nearest preceding concrete step.
+type PickleStepType string
+
+const (
+	PickleStepTypeContext PickleStepType = "Context"
+	PickleStepTypeAction  PickleStepType = "Action"
+	PickleStepTypeOutcome PickleStepType = "Outcome"
+	PickleStepTypeUnknown PickleStepType = "Unknown"
+)
+
+// PickleStep is a single step within a Pickle, with placeholder substitution
+// already applied.
+type PickleStep struct {
+	ID         string              `json:"id"`
+	Text       string              `json:"text"`
+	Type       PickleStepType      `json:"type"`
+	Argument   *PickleStepArgument `json:"-"`
+	AstNodeIDs []string            `json:"astNodeIds"`
+}
+
+// MarshalJSON flattens Argument so a PickleStep serializes with
+// "docString"/"dataTable" directly on the step, matching the Cucumber
+// Messages schema.
+func (s PickleStep) MarshalJSON() ([]byte, error) {
+	type wire struct {
+		ID         string           `json:"id"`
+		Text       string           `json:"text"`
+		Type       PickleStepType   `json:"type"`
+		AstNodeIDs []string         `json:"astNodeIds"`
+		DocString  *PickleDocString `json:"docString,omitempty"`
+		DataTable  *PickleTable     `json:"dataTable,omitempty"`
+	}
+	w := wire{ID: s.ID, Text: s.Text, Type: s.Type, AstNodeIDs: s.AstNodeIDs}
+	if s.Argument != nil {
+		w.DocString = s.Argument.DocString
+		w.DataTable = s.Argument.DataTable
+	}
+	return json.Marshal(w)
+}
+
+// PickleStepArgument is either a PickleDocString or a PickleTable attached to
+// a PickleStep.
+type PickleStepArgument struct {
+	DocString *PickleDocString
+	DataTable *PickleTable
+}
+
+// PickleDocString is a DocString with placeholder substitution applied.
+type PickleDocString struct {
+	MediaType string `json:"mediaType,omitempty"`
+	Content   string `json:"content"`
+}
+
+// PickleTable is a DataTable with placeholder substitution applied.
+type PickleTable struct {
+	Rows []PickleTableRow `json:"rows"`
+}
+
+// PickleTableRow is a row of a PickleTable.
+type PickleTableRow struct {
+	Cells []PickleTableCell `json:"cells"`
+}
+
+// PickleTableCell is a single cell of a PickleTableRow.
+type PickleTableCell struct {
+	Value string `json:"value"`
+}
+
+// CompileOptions configures [Compile].
+type CompileOptions struct {
+	// IDGenerator produces IDs for compiled Pickles and PickleSteps. If nil,
+	// Compile uses [NewIncrementingIDGenerator].
+	IDGenerator IDGenerator
+}
+
+// Compile lowers a parsed Document into the flattened, execution-ready
+// Pickles used by Cucumber runners: one Pickle per concrete scenario, with
+// Background steps (including any inherited from an enclosing Rule)
+// prepended, and Scenario Outlines expanded once per Examples row with
+// `<param>` placeholders substituted into step text, DocString content, and
+// DataTable cells.
+func Compile(doc *Document, uri string, opts ...CompileOptions) ([]Pickle, error) {
+	var opt CompileOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	gen := opt.IDGenerator
+	if gen == nil {
+		gen = NewIncrementingIDGenerator()
+	}
+
+	if doc == nil || doc.Feature == nil {
+		return nil, nil
+	}
+
+	c := &compiler{uri: uri, gen: gen, feature: doc.Feature}
+	return c.compileFeature(), nil
+}
+
+// Compile parses source and compiles it straight to Pickles, combining
+// [Engine.Parse] and [Compile] in one call.
+func (e *Engine) Compile(ctx context.Context, source, uri string, opts ...CompileOptions) ([]Pickle, error) {
+	doc, err := e.Parse(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(doc, uri, opts...)
+}
+
+// compiler holds the state threaded through one Compile call.
+type compiler struct {
+	uri     string
+	gen     IDGenerator
+	feature *Feature
+
+	// lastStepType tracks the most recent concrete (non-conjunction) step
+	// type seen by stepType, since And/But steps always resolve to it.
+	lastStepType PickleStepType
+}
+
+func (c *compiler) compileFeature() []Pickle {
+	var pickles []Pickle
+	var background *Background
+	for _, child := range c.feature.Children {
+		switch {
+		case child.Background != nil:
+			background = child.Background
+		case child.Scenario != nil:
+			pickles = append(pickles, c.compileScenario(child.Scenario, []*Background{background}, c.feature.Tags)...)
+		case child.Rule != nil:
+			pickles = append(pickles, c.compileRule(child.Rule, background)...)
+		}
+	}
+	return pickles
+}
+
+// compileRule compiles rule's scenarios, prepending featureBackground's
+// steps (if any) followed by the Rule's own background's steps, matching
+// how the reference Cucumber compilers scope a Feature-level Background to
+// every Rule that doesn't otherwise say anything about it.
+func (c *compiler) compileRule(rule *Rule, featureBackground *Background) []Pickle {
+	var ruleBackground *Background
+	var pickles []Pickle
+	inherited := mergeTags(c.feature.Tags, rule.Tags)
+	for _, child := range rule.Children {
+		switch {
+		case child.Background != nil:
+			ruleBackground = child.Background
+		case child.Scenario != nil:
+			pickles = append(pickles, c.compileScenario(child.Scenario, []*Background{featureBackground, ruleBackground}, inherited)...)
+		}
+	}
+	return pickles
+}
+
+// compileScenario compiles a single Scenario (or Scenario Outline) into one
+// or more Pickles, prepending the given backgrounds' steps (in order, e.g.
+// feature-level then rule-level) and inheriting the given ancestor tags.
+func (c *compiler) compileScenario(sc *Scenario, backgrounds []*Background, ancestorTags []Tag) []Pickle {
+	if sc.Kind == ScenarioKindScenarioOutline {
+		return c.compileOutline(sc, backgrounds, ancestorTags)
+	}
+
+	c.lastStepType = PickleStepTypeContext
+	steps := c.backgroundSteps(backgrounds)
+	for _, step := range sc.Steps {
+		steps = append(steps, c.compileStep(step, nil))
+	}
+
+	return []Pickle{{
+		ID:         c.gen.NewID(sc.ID),
+		URI:        c.uri,
+		Name:       sc.Name,
+		Language:   c.feature.Language,
+		Tags:       tagNames(mergeTags(ancestorTags, sc.Tags)),
+		AstNodeIDs: []string{sc.ID},
+		Steps:      steps,
+	}}
+}
+
+// compileOutline expands a Scenario Outline into one Pickle per Examples
+// row, via [Scenario.Expand] — which already applies `<param>` placeholder
+// substitution to step text, DocString content, and DataTable cells, so
+// compileStep is called with nil params here and only attaches AstNodeIDs.
+func (c *compiler) compileOutline(sc *Scenario, backgrounds []*Background, ancestorTags []Tag) []Pickle {
+	var pickles []Pickle
+	for _, cs := range sc.Expand() {
+		c.lastStepType = PickleStepTypeContext
+		steps := c.backgroundSteps(backgrounds)
+		for _, step := range cs.Steps {
+			extraNodeIDs := rowNodeID(cs.Row)
+			steps = append(steps, c.compileStep(step, nil, extraNodeIDs...))
+		}
+
+		pickles = append(pickles, Pickle{
+			ID:         c.gen.NewID(cs.ID),
+			URI:        c.uri,
+			Name:       cs.Name,
+			Language:   c.feature.Language,
+			Tags:       tagNames(mergeTags(ancestorTags, cs.Tags)),
+			AstNodeIDs: append([]string{sc.ID}, rowNodeID(cs.Row)...),
+			Steps:      steps,
+		})
+	}
+	return pickles
+}
+
+// rowNodeID returns row's ID as a single-element slice, or nil if row is
+// nil (e.g. when Expand() was called on a plain, non-outline Scenario).
+func rowNodeID(row *TableRow) []string {
+	if row == nil {
+		return nil
+	}
+	return []string{row.ID}
+}
+
+// backgroundSteps compiles the steps of zero or more (possibly nil)
+// Backgrounds, in order, none of which ever carry placeholder substitution.
+func (c *compiler) backgroundSteps(backgrounds []*Background) []PickleStep {
+	var steps []PickleStep
+	for _, background := range backgrounds {
+		if background == nil {
+			continue
+		}
+		for _, step := range background.Steps {
+			steps = append(steps, c.compileStep(step, nil))
+		}
+	}
+	return steps
+}
+
+// compileStep converts a single AST Step into a PickleStep, substituting
+// `<param>` placeholders from params (if non-nil) and recording extraNodeIDs
+// (e.g. the originating Examples row) alongside the step's own ID.
+func (c *compiler) compileStep(step Step, params map[string]string, extraNodeIDs ...string) PickleStep {
+	astNodeIDs := append([]string{step.ID}, extraNodeIDs...)
+	ps := PickleStep{
+		ID:         c.gen.NewID(strings.Join(astNodeIDs, ";")),
+		Text:       substitute(step.Text, params),
+		Type:       c.stepType(step.KeywordType),
+		AstNodeIDs: astNodeIDs,
+	}
+
+	if step.Argument != nil {
+		switch {
+		case step.Argument.DocString != nil:
+			ds := step.Argument.DocString
+			ps.Argument = &PickleStepArgument{DocString: &PickleDocString{
+				MediaType: ds.MediaType,
+				Content:   substitute(ds.Content, params),
+			}}
+		case step.Argument.DataTable != nil:
+			ps.Argument = &PickleStepArgument{DataTable: compileTable(step.Argument.DataTable, params)}
+		}
+	}
+
+	return ps
+}
+
+func (c *compiler) stepType(kt KeywordType) PickleStepType {
+	switch kt {
+	case KeywordTypeContext:
+		c.lastStepType = PickleStepTypeContext
+	case KeywordTypeAction:
+		c.lastStepType = PickleStepTypeAction
+	case KeywordTypeOutcome:
+		c.lastStepType = PickleStepTypeOutcome
+	case KeywordTypeConjunction:
+		// Resolves to whatever concrete type preceded it.
+	default:
+		c.lastStepType = PickleStepTypeUnknown
+	}
+	return c.lastStepType
+}
+
+func compileTable(dt *DataTable, params map[string]string) *PickleTable {
+	pt := &PickleTable{Rows: make([]PickleTableRow, len(dt.Rows))}
+	for i, row := range dt.Rows {
+		cells := make([]PickleTableCell, len(row.Cells))
+		for j, cell := range row.Cells {
+			cells[j] = PickleTableCell{Value: substitute(cell.Value, params)}
+		}
+		pt.Rows[i] = PickleTableRow{Cells: cells}
+	}
+	return pt
+}
+
+// substitute replaces every `<name>` placeholder in text with params[name].
+// Placeholders with no matching param are left untouched, matching the
+// behavior of the reference Cucumber implementations.
+//
+// Substitution happens in a single left-to-right pass over text: once a
+// `<name>` token has been replaced by its value, that value is never
+// re-scanned for further placeholders. Doing this via N sequential
+// strings.ReplaceAll calls (one per param, in Go's randomized map iteration
+// order) would make the result depend on iteration order whenever one
+// param's value itself looks like another param's placeholder — e.g.
+// params{"a": "<b>", "b": "VALUE"} substituting into "<a>" could legally
+// wind up as either "<b>" or "VALUE" depending on which ReplaceAll ran last.
+func substitute(text string, params map[string]string) string {
+	if len(params) == 0 || !strings.Contains(text, "<") {
+		return text
+	}
+
+	var b strings.Builder
+	rest := text
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start+1:], '>')
+		if end < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end += start + 1
+
+		name := rest[start+1 : end]
+		if value, ok := params[name]; ok {
+			b.WriteString(rest[:start])
+			b.WriteString(value)
+		} else {
+			b.WriteString(rest[:end+1])
+		}
+		rest = rest[end+1:]
+	}
+	return b.String()
+}
+
+func mergeTags(lists ...[]Tag) []Tag {
+	var merged []Tag
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, tag := range list {
+			if seen[tag.Name] {
+				continue
+			}
+			seen[tag.Name] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+func tagNames(tags []Tag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,393 @@
+package gherkin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func tag(name string) Tag { return Tag{Name: name} }
+
+func step(kt KeywordType, text string, arg *StepArgument) Step {
+	return Step{ID: "step:" + text, Keyword: string(kt), KeywordType: kt, Text: text, Argument: arg}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     *Document
+		uri     string
+		opts    []CompileOptions
+		want    []Pickle
+		wantErr bool
+	}{
+		{
+			name: "nil document",
+			doc:  nil,
+			uri:  "a.feature",
+			want: nil,
+		},
+		{
+			name: "feature with no Feature node",
+			doc:  &Document{},
+			uri:  "a.feature",
+			want: nil,
+		},
+		{
+			name: "plain scenario with background",
+			doc: &Document{Feature: &Feature{
+				Language: "en",
+				Tags:     []Tag{tag("@feature")},
+				Children: []FeatureChild{
+					{Background: &Background{
+						Steps: []Step{step(KeywordTypeContext, "a clean slate", nil)},
+					}},
+					{Scenario: &Scenario{
+						ID:    "sc1",
+						Kind:  ScenarioKindScenario,
+						Name:  "does a thing",
+						Tags:  []Tag{tag("@smoke")},
+						Steps: []Step{
+							step(KeywordTypeAction, "I do the thing", nil),
+							step(KeywordTypeOutcome, "it works", nil),
+							step(KeywordTypeConjunction, "it logs nothing", nil),
+						},
+					}},
+				},
+			}},
+			uri: "a.feature",
+			opts: []CompileOptions{{IDGenerator: NewIncrementingIDGenerator()}},
+			want: []Pickle{{
+				ID:         "5",
+				URI:        "a.feature",
+				Name:       "does a thing",
+				Language:   "en",
+				Tags:       []string{"@feature", "@smoke"},
+				AstNodeIDs: []string{"sc1"},
+				Steps: []PickleStep{
+					{ID: "1", Text: "a clean slate", Type: PickleStepTypeContext, AstNodeIDs: []string{"step:a clean slate"}},
+					{ID: "2", Text: "I do the thing", Type: PickleStepTypeAction, AstNodeIDs: []string{"step:I do the thing"}},
+					{ID: "3", Text: "it works", Type: PickleStepTypeOutcome, AstNodeIDs: []string{"step:it works"}},
+					{ID: "4", Text: "it logs nothing", Type: PickleStepTypeOutcome, AstNodeIDs: []string{"step:it logs nothing"}},
+				},
+			}},
+		},
+		{
+			name: "rule inherits feature and rule tags, background scoped to rule",
+			doc: &Document{Feature: &Feature{
+				Tags: []Tag{tag("@feature")},
+				Children: []FeatureChild{
+					{Rule: &Rule{
+						Tags: []Tag{tag("@rule")},
+						Children: []RuleChild{
+							{Background: &Background{Steps: []Step{step(KeywordTypeContext, "background step", nil)}}},
+							{Scenario: &Scenario{ID: "sc1", Kind: ScenarioKindScenario, Name: "in a rule", Steps: []Step{
+								step(KeywordTypeAction, "do it", nil),
+							}}},
+						},
+					}},
+				},
+			}},
+			uri:  "r.feature",
+			opts: []CompileOptions{{IDGenerator: NewIncrementingIDGenerator()}},
+			want: []Pickle{{
+				ID:         "3",
+				URI:        "r.feature",
+				Name:       "in a rule",
+				Tags:       []string{"@feature", "@rule"},
+				AstNodeIDs: []string{"sc1"},
+				Steps: []PickleStep{
+					{ID: "1", Text: "background step", Type: PickleStepTypeContext, AstNodeIDs: []string{"step:background step"}},
+					{ID: "2", Text: "do it", Type: PickleStepTypeAction, AstNodeIDs: []string{"step:do it"}},
+				},
+			}},
+		},
+		{
+			name: "feature background and rule background both prepended, in order",
+			doc: &Document{Feature: &Feature{
+				Children: []FeatureChild{
+					{Background: &Background{
+						Steps: []Step{step(KeywordTypeContext, "feature background", nil)},
+					}},
+					{Rule: &Rule{
+						Children: []RuleChild{
+							{Background: &Background{Steps: []Step{step(KeywordTypeContext, "rule background", nil)}}},
+							{Scenario: &Scenario{ID: "sc1", Kind: ScenarioKindScenario, Name: "in a rule", Steps: []Step{
+								step(KeywordTypeAction, "do it", nil),
+							}}},
+						},
+					}},
+				},
+			}},
+			uri:  "fr.feature",
+			opts: []CompileOptions{{IDGenerator: NewIncrementingIDGenerator()}},
+			want: []Pickle{{
+				ID:         "4",
+				URI:        "fr.feature",
+				Name:       "in a rule",
+				AstNodeIDs: []string{"sc1"},
+				Steps: []PickleStep{
+					{ID: "1", Text: "feature background", Type: PickleStepTypeContext, AstNodeIDs: []string{"step:feature background"}},
+					{ID: "2", Text: "rule background", Type: PickleStepTypeContext, AstNodeIDs: []string{"step:rule background"}},
+					{ID: "3", Text: "do it", Type: PickleStepTypeAction, AstNodeIDs: []string{"step:do it"}},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compile(tt.doc, tt.uri, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertPicklesEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestCompileOutlineExpandsOneRowPerExample(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Children: []FeatureChild{
+			{Scenario: &Scenario{
+				ID:   "outline1",
+				Kind: ScenarioKindScenarioOutline,
+				Name: "adding <a> and <b>",
+				Tags: []Tag{tag("@outline")},
+				Steps: []Step{
+					step(KeywordTypeContext, "I have <a> and <b>", nil),
+					step(KeywordTypeOutcome, "the sum is <sum>", nil),
+				},
+				Examples: []Examples{{
+					Name: "positive",
+					Tags: []Tag{tag("@positive")},
+					TableHeader: &TableRow{Cells: []TableCell{{Value: "a"}, {Value: "b"}, {Value: "sum"}}},
+					TableBody: []TableRow{
+						{ID: "row0", Cells: []TableCell{{Value: "1"}, {Value: "2"}, {Value: "3"}}},
+						{ID: "row1", Cells: []TableCell{{Value: "2"}, {Value: "2"}, {Value: "4"}}},
+					},
+				}},
+			}},
+		},
+	}}
+
+	got, err := Compile(doc, "o.feature")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d pickles, want 2", len(got))
+	}
+
+	for i, row := range []struct {
+		name string
+		sum  string
+	}{{"adding 1 and 2", "3"}, {"adding 2 and 2", "4"}} {
+		if got[i].Name != row.name {
+			t.Errorf("pickle[%d].Name = %q, want %q", i, got[i].Name, row.name)
+		}
+		wantTags := []string{"@outline", "@positive"}
+		if !stringsEqual(got[i].Tags, wantTags) {
+			t.Errorf("pickle[%d].Tags = %v, want %v", i, got[i].Tags, wantTags)
+		}
+		last := got[i].Steps[len(got[i].Steps)-1]
+		if want := "the sum is " + row.sum; last.Text != want {
+			t.Errorf("pickle[%d] last step = %q, want %q", i, last.Text, want)
+		}
+	}
+}
+
+func TestCompileStepArgumentsSubstituted(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Children: []FeatureChild{
+			{Scenario: &Scenario{
+				ID:   "outline1",
+				Kind: ScenarioKindScenarioOutline,
+				Name: "scn",
+				Steps: []Step{
+					step(KeywordTypeContext, "the doc", &StepArgument{
+						DocString: &DocString{Content: "hello <name>"},
+					}),
+					step(KeywordTypeAction, "the table", &StepArgument{
+						DataTable: &DataTable{Rows: []TableRow{
+							{Cells: []TableCell{{Value: "<name>"}, {Value: "static"}}},
+						}},
+					}),
+				},
+				Examples: []Examples{{
+					TableHeader: &TableRow{Cells: []TableCell{{Value: "name"}}},
+					TableBody:   []TableRow{{ID: "row0", Cells: []TableCell{{Value: "Ada"}}}},
+				}},
+			}},
+		},
+	}}
+
+	got, err := Compile(doc, "d.feature")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d pickles, want 1", len(got))
+	}
+
+	steps := got[0].Steps
+	if ds := steps[0].Argument.DocString; ds == nil || ds.Content != "hello Ada" {
+		t.Errorf("DocString content = %+v, want %q", ds, "hello Ada")
+	}
+	if dt := steps[1].Argument.DataTable; dt == nil || dt.Rows[0].Cells[0].Value != "Ada" || dt.Rows[0].Cells[1].Value != "static" {
+		t.Errorf("DataTable cells = %+v, want [Ada static]", dt)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		params map[string]string
+		want   string
+	}{
+		{name: "no params", text: "<a>", params: nil, want: "<a>"},
+		{name: "no angle brackets", text: "plain text", params: map[string]string{"a": "X"}, want: "plain text"},
+		{name: "simple substitution", text: "a <x> b", params: map[string]string{"x": "VALUE"}, want: "a VALUE b"},
+		{name: "unmatched placeholder left alone", text: "<missing>", params: map[string]string{"x": "VALUE"}, want: "<missing>"},
+		{name: "unterminated placeholder left alone", text: "a <x b", params: map[string]string{"x": "VALUE"}, want: "a <x b"},
+		{
+			// Regression test: a param's value must never be re-scanned for
+			// further placeholders, and the result must not depend on map
+			// iteration order.
+			name:   "substituted value is not rescanned",
+			text:   "start <a> end",
+			params: map[string]string{"a": "<b>", "b": "VALUE"},
+			want:   "start <b> end",
+		},
+		{
+			name:   "multiple placeholders substituted left to right",
+			text:   "<a>-<b>-<a>",
+			params: map[string]string{"a": "1", "b": "2"},
+			want:   "1-2-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := substitute(tt.text, tt.params); got != tt.want {
+					t.Fatalf("substitute(%q, %v) = %q, want %q", tt.text, tt.params, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIncrementingIDGenerator(t *testing.T) {
+	gen := NewIncrementingIDGenerator()
+	for i, want := range []string{"1", "2", "3"} {
+		if got := gen.NewID("irrelevant"); got != want {
+			t.Errorf("call %d: NewID() = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestHashIDGeneratorStableAcrossInstances(t *testing.T) {
+	a := NewHashIDGenerator("features/foo.feature")
+	b := NewHashIDGenerator("features/foo.feature")
+	if a.NewID("sc1") != b.NewID("sc1") {
+		t.Fatalf("hash generator IDs differ for the same seed and node ID")
+	}
+
+	c := NewHashIDGenerator("features/bar.feature")
+	if a.NewID("sc1") == c.NewID("sc1") {
+		t.Fatalf("hash generator produced the same ID for different seeds")
+	}
+}
+
+// TestHashIDGeneratorStableAcrossReordering is the property the generator
+// exists for: a pickle's ID depends on its own AST node ID, not on how many
+// other pickles were generated before it, so inserting, deleting, or
+// reordering unrelated scenarios in the same file never reassigns an
+// untouched scenario's ID.
+func TestHashIDGeneratorStableAcrossReordering(t *testing.T) {
+	gen := NewHashIDGenerator("features/foo.feature")
+	first := gen.NewID("sc1")
+
+	// Generate a bunch of unrelated IDs in between, simulating other
+	// scenarios compiled earlier or later in the same file.
+	for i := 0; i < 5; i++ {
+		gen.NewID("sc-unrelated")
+	}
+
+	if got := gen.NewID("sc1"); got != first {
+		t.Fatalf("NewID(%q) = %q after intervening calls, want %q (unchanged)", "sc1", got, first)
+	}
+
+	if other := gen.NewID("sc2"); other == first {
+		t.Fatalf("NewID produced the same ID for different node IDs %q and %q", "sc1", "sc2")
+	}
+}
+
+func TestPickleStepMarshalJSONFlattensArgument(t *testing.T) {
+	ps := PickleStep{
+		ID:   "s1",
+		Text: "a doc string step",
+		Type: PickleStepTypeContext,
+		Argument: &PickleStepArgument{
+			DocString: &PickleDocString{Content: "hello"},
+		},
+	}
+	b, err := json.Marshal(ps)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if _, ok := decoded["docString"]; !ok {
+		t.Errorf("marshaled step missing top-level docString field: %s", b)
+	}
+	if _, ok := decoded["dataTable"]; ok {
+		t.Errorf("marshaled step has unexpected dataTable field: %s", b)
+	}
+}
+
+func assertPicklesEqual(t *testing.T, got, want []Pickle) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d pickles, want %d (got=%+v)", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.ID != w.ID || g.URI != w.URI || g.Name != w.Name || g.Language != w.Language {
+			t.Errorf("pickle[%d] = %+v, want %+v", i, g, w)
+		}
+		if !stringsEqual(g.Tags, w.Tags) {
+			t.Errorf("pickle[%d].Tags = %v, want %v", i, g.Tags, w.Tags)
+		}
+		if !stringsEqual(g.AstNodeIDs, w.AstNodeIDs) {
+			t.Errorf("pickle[%d].AstNodeIDs = %v, want %v", i, g.AstNodeIDs, w.AstNodeIDs)
+		}
+		if len(g.Steps) != len(w.Steps) {
+			t.Fatalf("pickle[%d] has %d steps, want %d", i, len(g.Steps), len(w.Steps))
+		}
+		for j := range w.Steps {
+			gs, ws := g.Steps[j], w.Steps[j]
+			if gs.ID != ws.ID || gs.Text != ws.Text || gs.Type != ws.Type {
+				t.Errorf("pickle[%d].Steps[%d] = %+v, want %+v", i, j, gs, ws)
+			}
+			if !stringsEqual(gs.AstNodeIDs, ws.AstNodeIDs) {
+				t.Errorf("pickle[%d].Steps[%d].AstNodeIDs = %v, want %v", i, j, gs.AstNodeIDs, ws.AstNodeIDs)
+			}
+		}
+	}
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+package gherkin
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrEngineClosed is reported by the pooled calls (via acquireInstance) when
+// the Engine's pool is closed concurrently, e.g. by [Engine.Close] racing
+// with in-flight batch workers: [Engine.ParseBatch] records it in a
+// BatchResult's Err, and [Engine.ParseStream] sends it as an error event.
+var ErrEngineClosed = errors.New("gherkin: engine is closed")
+
+// NamedSource identifies one input to [Engine.ParseBatch] by URI. It has
+// the same shape as [Source] (used by [Engine.Messages]) since both just
+// pair a URI with raw Gherkin text — kept as a distinct name here since
+// that's the vocabulary batch-parsing callers expect.
+type NamedSource = Source
+
+// BatchResult is one [Engine.ParseBatch] outcome: exactly one of Document
+// or Err is set.
+type BatchResult struct {
+	URI      string
+	Document *Document
+	Err      error
+}
+
+// ParseBatch parses every source concurrently, using up to concurrency
+// workers drawn from a reservoir of pooled WASM instances (see
+// [WithMaxInstances]) instead of instantiating a fresh module per call the
+// way [Engine.Parse] does. For a suite of hundreds of `.feature` files this
+// amortizes the AOT-compiled module's instantiation cost across many
+// parses.
+//
+// Results are returned in the same order as sources. A per-source parse
+// error is recorded in that result's Err field rather than aborting the
+// batch; ParseBatch itself only returns an error if ctx is canceled before
+// every source finishes.
+func (e *Engine) ParseBatch(ctx context.Context, sources []NamedSource, concurrency int) ([]BatchResult, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]BatchResult, len(sources))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, src := range sources {
+		i, src := i, src
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return results, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			doc, err := e.parsePooled(ctx, src.Data)
+			results[i] = BatchResult{URI: src.URI, Document: doc, Err: err}
+		}()
+	}
+
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+// parsePooled parses source using an instance drawn from e.pool, returning
+// it to the pool afterward instead of closing it.
+func (e *Engine) parsePooled(ctx context.Context, source string) (*Document, error) {
+	inst, err := e.acquireInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer e.releaseInstance(inst)
+
+	retptr, err := inst.callWithSource(ctx, exportParse, source)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := inst.decodeParseResult(retptr)
+	inst.callPostReturn(ctx, exportParsePost, retptr)
+	return doc, err
+}
+
+// tokenizePooled tokenizes source using an instance drawn from e.pool,
+// returning it to the pool afterward instead of closing it. It's
+// [Engine.parsePooled]'s counterpart for [Engine.TokenizeStream].
+func (e *Engine) tokenizePooled(ctx context.Context, source string) ([]Token, error) {
+	inst, err := e.acquireInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer e.releaseInstance(inst)
+
+	retptr, err := inst.callWithSource(ctx, exportTokenize, source)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := inst.decodeTokenizeResult(retptr)
+	inst.callPostReturn(ctx, exportTokenizePost, retptr)
+	return tokens, err
+}
+
+// acquireInstance returns an idle pooled instance, creates a new one if the
+// reservoir hasn't reached e.maxInstances yet, or blocks until one is
+// released back to the pool.
+//
+// Instances are never reset between jobs beyond what the parse export's own
+// post-return hook frees: the underlying WASM allocator has no free(), so a
+// long-lived pooled instance's linear memory grows monotonically with reuse.
+// The pool bounds concurrent instances, not per-instance memory.
+func (e *Engine) acquireInstance(ctx context.Context) (*instance, error) {
+	select {
+	case inst, ok := <-e.pool:
+		if !ok {
+			return nil, ErrEngineClosed
+		}
+		return inst, nil
+	default:
+	}
+
+	if e.created.Add(1) <= int64(e.maxInstances) {
+		inst, err := e.newInstance(ctx)
+		if err != nil {
+			e.created.Add(-1)
+			return nil, err
+		}
+		return inst, nil
+	}
+	e.created.Add(-1)
+
+	select {
+	case inst, ok := <-e.pool:
+		if !ok {
+			return nil, ErrEngineClosed
+		}
+		return inst, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseInstance returns inst to the pool for reuse, or closes it directly
+// if the pool is full or the Engine has been closed.
+func (e *Engine) releaseInstance(inst *instance) {
+	if !e.tryRelease(inst) {
+		inst.close(context.Background())
+	}
+}
+
+// tryRelease attempts to send inst into e.pool, reporting whether it
+// succeeded. It's split out from releaseInstance so the synchronization
+// itself — the part that must agree with [Engine.Close] about when the pool
+// is closed — can be tested without a real WASM instance.
+//
+// Sending on e.pool is guarded by poolMu (the same lock Close takes before
+// closing e.pool) rather than relying on select's default case: a select
+// with a send case still panics if the channel happens to be closed
+// concurrently, so closed must be checked and the send performed under one
+// lock shared with Close.
+func (e *Engine) tryRelease(inst *instance) bool {
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	if e.closed {
+		return false
+	}
+	select {
+	case e.pool <- inst:
+		return true
+	default:
+		// Reservoir is unexpectedly full; caller closes inst instead.
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package gherkin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestAcquireInstanceClosedPool(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 1)}
+	close(e.pool)
+
+	if _, err := e.acquireInstance(context.Background()); !errors.Is(err, ErrEngineClosed) {
+		t.Fatalf("acquireInstance() error = %v, want %v", err, ErrEngineClosed)
+	}
+}
+
+func TestAcquireInstanceReturnsPooledInstance(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 1)}
+	want := &instance{}
+	e.pool <- want
+
+	got, err := e.acquireInstance(context.Background())
+	if err != nil {
+		t.Fatalf("acquireInstance() error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("acquireInstance() = %p, want %p", got, want)
+	}
+}
+
+func TestTryReleaseSendsToPool(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 1)}
+	inst := &instance{}
+
+	if !e.tryRelease(inst) {
+		t.Fatal("tryRelease() = false, want true")
+	}
+	if got := <-e.pool; got != inst {
+		t.Fatalf("pool received %p, want %p", got, inst)
+	}
+}
+
+func TestTryReleaseFullPool(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 1)}
+	e.pool <- &instance{}
+
+	if e.tryRelease(&instance{}) {
+		t.Fatal("tryRelease() = true on a full pool, want false")
+	}
+}
+
+// TestTryReleaseAfterClose is the regression test for the closed-channel
+// send panic: once the pool is marked closed (as [Engine.Close] does under
+// poolMu before closing the channel), tryRelease must report false instead
+// of attempting to send on it.
+func TestTryReleaseAfterClose(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 1)}
+
+	e.poolMu.Lock()
+	e.closed = true
+	close(e.pool)
+	e.poolMu.Unlock()
+
+	if e.tryRelease(&instance{}) {
+		t.Fatal("tryRelease() = true on a closed Engine, want false")
+	}
+}
+
+// TestTryReleaseConcurrentWithClose races tryRelease against the same
+// close-under-poolMu sequence Engine.Close uses, under -race: a tryRelease
+// that raced ahead of Close's lock must never observe a closed channel as
+// open, and Close must never close the channel while tryRelease is
+// mid-send. Either would panic with "send on closed channel".
+func TestTryReleaseConcurrentWithClose(t *testing.T) {
+	e := &Engine{pool: make(chan *instance, 4)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.tryRelease(&instance{})
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		e.poolMu.Lock()
+		e.closed = true
+		close(e.pool)
+		e.poolMu.Unlock()
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,167 @@
+package gherkin
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// DocumentEventType identifies which field of a DocumentEvent is populated.
+type DocumentEventType string
+
+const (
+	DocumentEventFeatureStart  DocumentEventType = "feature-start"
+	DocumentEventBackground    DocumentEventType = "background"
+	DocumentEventScenarioStart DocumentEventType = "scenario-start"
+	DocumentEventScenarioEnd   DocumentEventType = "scenario-end"
+	DocumentEventStep          DocumentEventType = "step"
+	DocumentEventTableRow      DocumentEventType = "table-row"
+	DocumentEventDocString     DocumentEventType = "doc-string"
+	DocumentEventComment       DocumentEventType = "comment"
+	DocumentEventError         DocumentEventType = "error"
+)
+
+// DocumentEvent is one step of a Document walk, emitted in source order on
+// the channel returned by [Engine.ParseStream]. Exactly one payload field is
+// set, matching Type, except that a table-row event for an Examples row also
+// sets Examples.
+type DocumentEvent struct {
+	Type DocumentEventType
+
+	Feature    *Feature
+	Background *Background
+	Scenario   *Scenario
+	Step       *Step
+	// Examples is set alongside TableRow when the row came from a Scenario
+	// Outline's Examples table rather than a step's DataTable.
+	Examples  *Examples
+	TableRow  *TableRow
+	DocString *DocString
+	Comment   *Comment
+	Err       error
+}
+
+// ParseStream reads all of r, parses it, and reports the result as a stream
+// of DocumentEvents instead of a materialized *Document — useful when a
+// caller wants to start reacting to a feature file (e.g. streaming it to a
+// UI) or processing many concatenated files without holding every one's
+// *Document at once.
+//
+// This is an ergonomic, channel-based wrapper, not a chunked or incremental
+// parser: Gherkin's grammar can't be parsed a chunk at a time (a
+// Background's scope, for instance, isn't known until the whole Feature is
+// seen), so ParseStream still decodes r's full contents in one WASM call —
+// the same O(N) up-front decode [Engine.Parse] does — and only streams
+// events off the resulting AST afterward. What it does improve on Parse is
+// reusing e's pooled WASM instances (see [Engine.ParseBatch]) for that
+// decode instead of instantiating a fresh module per call, which matters
+// when a caller streams many files back to back. See [Parser] for the
+// equivalent callback-based API.
+//
+// The channel is closed once every event has been sent or ctx is canceled.
+// A parse error is delivered as a single DocumentEventError and then the
+// channel is closed.
+func (e *Engine) ParseStream(ctx context.Context, r io.Reader) (<-chan DocumentEvent, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read source: %w", err)
+	}
+
+	ch := make(chan DocumentEvent)
+	go func() {
+		defer close(ch)
+
+		send := func(ev DocumentEvent) bool {
+			select {
+			case ch <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		doc, err := e.parsePooled(ctx, string(data))
+		if err != nil {
+			send(DocumentEvent{Type: DocumentEventError, Err: err})
+			return
+		}
+
+		walkDocument(doc, EventHandler{
+			OnFeature: func(f *Feature) {
+				send(DocumentEvent{Type: DocumentEventFeatureStart, Feature: f})
+			},
+			OnBackground: func(b *Background) {
+				send(DocumentEvent{Type: DocumentEventBackground, Background: b})
+			},
+			OnScenarioStart: func(s *Scenario) {
+				send(DocumentEvent{Type: DocumentEventScenarioStart, Scenario: s})
+			},
+			OnScenarioEnd: func(s *Scenario) {
+				send(DocumentEvent{Type: DocumentEventScenarioEnd, Scenario: s})
+			},
+			OnStep: func(step Step) {
+				if !send(DocumentEvent{Type: DocumentEventStep, Step: &step}) {
+					return
+				}
+				if step.Argument == nil {
+					return
+				}
+				if dt := step.Argument.DataTable; dt != nil {
+					for _, row := range dt.Rows {
+						row := row
+						if !send(DocumentEvent{Type: DocumentEventTableRow, TableRow: &row}) {
+							return
+						}
+					}
+				}
+				if ds := step.Argument.DocString; ds != nil {
+					send(DocumentEvent{Type: DocumentEventDocString, DocString: ds})
+				}
+			},
+			OnExamplesRow: func(ex *Examples, row TableRow, _ int) {
+				send(DocumentEvent{Type: DocumentEventTableRow, Examples: ex, TableRow: &row})
+			},
+			OnComment: func(c Comment) {
+				send(DocumentEvent{Type: DocumentEventComment, Comment: &c})
+			},
+		})
+	}()
+
+	return ch, nil
+}
+
+// TokenizeStream reads all of r, tokenizes it, and streams the resulting
+// [Token] values on a channel in source order, for callers processing very
+// large or many concatenated feature files who don't want to hold the full
+// token slice at once. Like [Engine.ParseStream], it's a channel wrapper
+// around one full WASM tokenize call (drawn from e's pooled instances, not
+// a fresh one per call), not an incremental tokenizer.
+//
+// The channel is closed once every token has been sent or ctx is canceled.
+// A tokenize error simply closes the channel with no tokens sent; callers
+// that need the error itself should use [Engine.Tokenize] directly.
+func (e *Engine) TokenizeStream(ctx context.Context, r io.Reader) (<-chan Token, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read source: %w", err)
+	}
+
+	ch := make(chan Token)
+	go func() {
+		defer close(ch)
+
+		tokens, err := e.tokenizePooled(ctx, string(data))
+		if err != nil {
+			return
+		}
+		for _, tok := range tokens {
+			select {
+			case ch <- tok:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,328 @@
+package gherkin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TagFilter evaluates a Cucumber tag expression against a set of tags
+// (feature ∪ rule ∪ scenario ∪ examples, by name) present on a scenario.
+// Build one with [CompileTagExpression].
+type TagFilter interface {
+	Eval(tags map[string]bool) bool
+}
+
+// Atom matches a single tag by name, e.g. "@smoke".
+type Atom struct{ Tag string }
+
+// Eval implements [TagFilter].
+func (a Atom) Eval(tags map[string]bool) bool { return tags[a.Tag] }
+
+// Not inverts Expr's result.
+type Not struct{ Expr TagFilter }
+
+// Eval implements [TagFilter].
+func (n Not) Eval(tags map[string]bool) bool { return !n.Expr.Eval(tags) }
+
+// And matches when both Left and Right match.
+type And struct{ Left, Right TagFilter }
+
+// Eval implements [TagFilter].
+func (a And) Eval(tags map[string]bool) bool { return a.Left.Eval(tags) && a.Right.Eval(tags) }
+
+// Or matches when either Left or Right matches.
+type Or struct{ Left, Right TagFilter }
+
+// Eval implements [TagFilter].
+func (o Or) Eval(tags map[string]bool) bool { return o.Left.Eval(tags) || o.Right.Eval(tags) }
+
+// CompileTagExpression parses a Cucumber tag expression — atoms are `@tag`
+// identifiers, operators are `and`, `or`, `not` (case-insensitive) with
+// parentheses for grouping, precedence is `not` > `and` > `or` — into a
+// [TagFilter] tree.
+//
+// Examples: "@smoke", "@smoke and not @wip", "(@a or @b) and @c".
+func CompileTagExpression(expr string) (TagFilter, error) {
+	tokens, err := tokenizeTagExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("gherkin: empty tag expression")
+	}
+	rpn, err := tagExprToRPN(tokens)
+	if err != nil {
+		return nil, err
+	}
+	return buildTagFilter(rpn)
+}
+
+type tagTokenKind int
+
+const (
+	tagTokAtom tagTokenKind = iota
+	tagTokAnd
+	tagTokOr
+	tagTokNot
+	tagTokLParen
+	tagTokRParen
+)
+
+type tagToken struct {
+	kind tagTokenKind
+	atom string
+}
+
+// tokenizeTagExpr splits expr into atoms, operator keywords, and
+// parentheses. Everything outside parentheses and whitespace is a single
+// word: `and`/`or`/`not` (any case) become operators, everything else must
+// be a `@`-prefixed tag.
+func tokenizeTagExpr(expr string) ([]tagToken, error) {
+	var tokens []tagToken
+	i := 0
+	for i < len(expr) {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, tagToken{kind: tagTokLParen})
+			i++
+		case c == ')':
+			tokens = append(tokens, tagToken{kind: tagTokRParen})
+			i++
+		default:
+			j := i
+			for j < len(expr) && !strings.ContainsRune(" \t\n()", rune(expr[j])) {
+				j++
+			}
+			word := expr[i:j]
+			i = j
+
+			switch strings.ToLower(word) {
+			case "and":
+				tokens = append(tokens, tagToken{kind: tagTokAnd})
+			case "or":
+				tokens = append(tokens, tagToken{kind: tagTokOr})
+			case "not":
+				tokens = append(tokens, tagToken{kind: tagTokNot})
+			default:
+				if !strings.HasPrefix(word, "@") {
+					return nil, fmt.Errorf("gherkin: invalid tag expression: expected a tag starting with '@', got %q", word)
+				}
+				tokens = append(tokens, tagToken{kind: tagTokAtom, atom: word})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+var tagOpPrecedence = map[tagTokenKind]int{tagTokNot: 3, tagTokAnd: 2, tagTokOr: 1}
+
+func isTagOperator(k tagTokenKind) bool {
+	return k == tagTokAnd || k == tagTokOr || k == tagTokNot
+}
+
+// tagExprToRPN runs the shunting-yard algorithm over tokens, producing
+// Reverse Polish Notation. `not` is right-associative; `and`/`or` are
+// left-associative.
+func tagExprToRPN(tokens []tagToken) ([]tagToken, error) {
+	var output, ops []tagToken
+
+	for _, t := range tokens {
+		switch {
+		case t.kind == tagTokAtom:
+			output = append(output, t)
+
+		case isTagOperator(t.kind):
+			for len(ops) > 0 && isTagOperator(ops[len(ops)-1].kind) {
+				top := ops[len(ops)-1]
+				topHigher := tagOpPrecedence[top.kind] > tagOpPrecedence[t.kind]
+				topEqualLeftAssoc := tagOpPrecedence[top.kind] == tagOpPrecedence[t.kind] && t.kind != tagTokNot
+				if !topHigher && !topEqualLeftAssoc {
+					break
+				}
+				output = append(output, top)
+				ops = ops[:len(ops)-1]
+			}
+			ops = append(ops, t)
+
+		case t.kind == tagTokLParen:
+			ops = append(ops, t)
+
+		case t.kind == tagTokRParen:
+			found := false
+			for len(ops) > 0 {
+				top := ops[len(ops)-1]
+				ops = ops[:len(ops)-1]
+				if top.kind == tagTokLParen {
+					found = true
+					break
+				}
+				output = append(output, top)
+			}
+			if !found {
+				return nil, fmt.Errorf("gherkin: unbalanced parentheses in tag expression")
+			}
+		}
+	}
+
+	for len(ops) > 0 {
+		top := ops[len(ops)-1]
+		ops = ops[:len(ops)-1]
+		if top.kind == tagTokLParen {
+			return nil, fmt.Errorf("gherkin: unbalanced parentheses in tag expression")
+		}
+		output = append(output, top)
+	}
+
+	return output, nil
+}
+
+// buildTagFilter evaluates an RPN token stream into a TagFilter tree.
+func buildTagFilter(rpn []tagToken) (TagFilter, error) {
+	var stack []TagFilter
+
+	for _, t := range rpn {
+		switch t.kind {
+		case tagTokAtom:
+			stack = append(stack, Atom{Tag: t.atom})
+
+		case tagTokNot:
+			if len(stack) < 1 {
+				return nil, fmt.Errorf("gherkin: malformed tag expression")
+			}
+			x := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stack = append(stack, Not{Expr: x})
+
+		case tagTokAnd, tagTokOr:
+			if len(stack) < 2 {
+				return nil, fmt.Errorf("gherkin: malformed tag expression")
+			}
+			right := stack[len(stack)-1]
+			left := stack[len(stack)-2]
+			stack = stack[:len(stack)-2]
+			if t.kind == tagTokAnd {
+				stack = append(stack, And{Left: left, Right: right})
+			} else {
+				stack = append(stack, Or{Left: left, Right: right})
+			}
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("gherkin: malformed tag expression")
+	}
+	return stack[0], nil
+}
+
+// FilterByTags returns a copy of d containing only the scenarios (and, for
+// Scenario Outlines, only the Examples blocks) whose inherited tags —
+// feature ∪ rule ∪ scenario ∪ examples — satisfy filter. Rules and Features
+// that end up with no matching children are dropped entirely; the result is
+// still a well-formed Document, round-trippable through [Engine.Format].
+func (d *Document) FilterByTags(filter TagFilter) *Document {
+	if d == nil || d.Feature == nil {
+		return d
+	}
+	return &Document{
+		Feature:  filterFeatureByTags(d.Feature, filter),
+		Comments: d.Comments,
+	}
+}
+
+// ParseFiltered parses source and returns only the scenarios matching expr,
+// combining [Engine.Parse], [CompileTagExpression], and
+// [Document.FilterByTags] in one call.
+func (e *Engine) ParseFiltered(ctx context.Context, source, expr string) (*Document, error) {
+	filter, err := CompileTagExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := e.Parse(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return doc.FilterByTags(filter), nil
+}
+
+func filterFeatureByTags(f *Feature, filter TagFilter) *Feature {
+	nf := *f
+	nf.Children = nil
+	hasScenario := false
+	for _, child := range f.Children {
+		switch {
+		case child.Background != nil:
+			nf.Children = append(nf.Children, child)
+		case child.Scenario != nil:
+			if sc := filterScenarioByTags(child.Scenario, nil, f.Tags, filter); sc != nil {
+				nf.Children = append(nf.Children, FeatureChild{Scenario: sc})
+				hasScenario = true
+			}
+		case child.Rule != nil:
+			if r := filterRuleByTags(child.Rule, f.Tags, filter); r != nil {
+				nf.Children = append(nf.Children, FeatureChild{Rule: r})
+				hasScenario = true
+			}
+		}
+	}
+	if !hasScenario {
+		return nil
+	}
+	return &nf
+}
+
+func filterRuleByTags(r *Rule, featureTags []Tag, filter TagFilter) *Rule {
+	nr := *r
+	nr.Children = nil
+	hasScenario := false
+	for _, child := range r.Children {
+		switch {
+		case child.Background != nil:
+			nr.Children = append(nr.Children, child)
+		case child.Scenario != nil:
+			if sc := filterScenarioByTags(child.Scenario, r.Tags, featureTags, filter); sc != nil {
+				nr.Children = append(nr.Children, RuleChild{Scenario: sc})
+				hasScenario = true
+			}
+		}
+	}
+	if !hasScenario {
+		return nil
+	}
+	return &nr
+}
+
+// filterScenarioByTags returns sc if it (or, for outlines, at least one of
+// its Examples blocks) matches filter, or nil otherwise. For an outline, the
+// returned Scenario keeps only the matching Examples blocks.
+func filterScenarioByTags(sc *Scenario, ruleTags, featureTags []Tag, filter TagFilter) *Scenario {
+	if sc.Kind != ScenarioKindScenarioOutline {
+		if filter.Eval(tagNameSet(mergeTags(featureTags, ruleTags, sc.Tags))) {
+			return sc
+		}
+		return nil
+	}
+
+	nsc := *sc
+	nsc.Examples = nil
+	for _, ex := range sc.Examples {
+		tags := tagNameSet(mergeTags(featureTags, ruleTags, sc.Tags, ex.Tags))
+		if filter.Eval(tags) {
+			nsc.Examples = append(nsc.Examples, ex)
+		}
+	}
+	if len(nsc.Examples) == 0 {
+		return nil
+	}
+	return &nsc
+}
+
+func tagNameSet(tags []Tag) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		set[t.Name] = true
+	}
+	return set
+}
@@ -0,0 +1,161 @@
+package gherkin
+
+import "testing"
+
+func TestCompileTagExpressionEval(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		tags map[string]bool
+		want bool
+	}{
+		{name: "single atom matches", expr: "@smoke", tags: map[string]bool{"@smoke": true}, want: true},
+		{name: "single atom no match", expr: "@smoke", tags: map[string]bool{"@wip": true}, want: false},
+		{name: "not inverts", expr: "not @wip", tags: map[string]bool{"@smoke": true}, want: true},
+		{name: "and requires both", expr: "@smoke and @fast", tags: map[string]bool{"@smoke": true}, want: false},
+		{name: "or requires either", expr: "@smoke or @fast", tags: map[string]bool{"@fast": true}, want: true},
+		{
+			name: "parens and precedence",
+			expr: "(@a or @b) and not @c",
+			tags: map[string]bool{"@b": true},
+			want: true,
+		},
+		{
+			name: "parens and precedence excluded by not",
+			expr: "(@a or @b) and not @c",
+			tags: map[string]bool{"@b": true, "@c": true},
+			want: false,
+		},
+		{name: "case insensitive operators", expr: "@a AND NOT @b", tags: map[string]bool{"@a": true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := CompileTagExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileTagExpression(%q) error = %v", tt.expr, err)
+			}
+			if got := filter.Eval(tt.tags); got != tt.want {
+				t.Errorf("Eval(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileTagExpressionErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"not-a-tag",
+		"(@a and @b",
+		"@a)",
+		"@a and",
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := CompileTagExpression(expr); err == nil {
+				t.Errorf("CompileTagExpression(%q) error = nil, want an error", expr)
+			}
+		})
+	}
+}
+
+func TestFilterByTagsDropsNonMatchingScenario(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Name: "f",
+		Children: []FeatureChild{
+			{Scenario: &Scenario{ID: "sc1", Kind: ScenarioKindScenario, Name: "kept", Tags: []Tag{tag("@smoke")}}},
+			{Scenario: &Scenario{ID: "sc2", Kind: ScenarioKindScenario, Name: "dropped", Tags: []Tag{tag("@slow")}}},
+		},
+	}}
+	filter, err := CompileTagExpression("@smoke")
+	if err != nil {
+		t.Fatalf("CompileTagExpression() error = %v", err)
+	}
+
+	got := doc.FilterByTags(filter)
+	if len(got.Feature.Children) != 1 {
+		t.Fatalf("got %d children, want 1", len(got.Feature.Children))
+	}
+	if got.Feature.Children[0].Scenario.Name != "kept" {
+		t.Errorf("kept scenario = %q, want %q", got.Feature.Children[0].Scenario.Name, "kept")
+	}
+}
+
+// TestFilterByTagsDropsEmptyFeature is the regression test for a Feature
+// whose every scenario is filtered out: it must come back nil, exactly like
+// an empty Rule does, rather than as a dangling, childless *Feature.
+func TestFilterByTagsDropsEmptyFeature(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Name: "f",
+		Children: []FeatureChild{
+			{Scenario: &Scenario{ID: "sc1", Kind: ScenarioKindScenario, Name: "dropped", Tags: []Tag{tag("@slow")}}},
+		},
+	}}
+	filter, err := CompileTagExpression("@smoke")
+	if err != nil {
+		t.Fatalf("CompileTagExpression() error = %v", err)
+	}
+
+	got := doc.FilterByTags(filter)
+	if got.Feature != nil {
+		t.Errorf("FilterByTags() Feature = %+v, want nil", got.Feature)
+	}
+}
+
+func TestFilterByTagsDropsEmptyRuleButKeepsFeature(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Name: "f",
+		Children: []FeatureChild{
+			{Scenario: &Scenario{ID: "sc1", Kind: ScenarioKindScenario, Name: "kept", Tags: []Tag{tag("@smoke")}}},
+			{Rule: &Rule{Name: "r", Children: []RuleChild{
+				{Scenario: &Scenario{ID: "sc2", Kind: ScenarioKindScenario, Name: "dropped", Tags: []Tag{tag("@slow")}}},
+			}}},
+		},
+	}}
+	filter, err := CompileTagExpression("@smoke")
+	if err != nil {
+		t.Fatalf("CompileTagExpression() error = %v", err)
+	}
+
+	got := doc.FilterByTags(filter)
+	if got.Feature == nil {
+		t.Fatal("FilterByTags() Feature = nil, want the feature with its matching scenario kept")
+	}
+	if len(got.Feature.Children) != 1 || got.Feature.Children[0].Rule != nil {
+		t.Errorf("got.Feature.Children = %+v, want only the kept scenario, no empty rule", got.Feature.Children)
+	}
+}
+
+func TestFilterByTagsKeepsOnlyMatchingExamplesRows(t *testing.T) {
+	doc := &Document{Feature: &Feature{
+		Children: []FeatureChild{
+			{Scenario: &Scenario{
+				ID:   "outline1",
+				Kind: ScenarioKindScenarioOutline,
+				Name: "outline",
+				Examples: []Examples{
+					{Name: "fast", Tags: []Tag{tag("@fast")}, TableHeader: &TableRow{}, TableBody: []TableRow{{ID: "row0"}}},
+					{Name: "slow", Tags: []Tag{tag("@slow")}, TableHeader: &TableRow{}, TableBody: []TableRow{{ID: "row1"}}},
+				},
+			}},
+		},
+	}}
+	filter, err := CompileTagExpression("@fast")
+	if err != nil {
+		t.Fatalf("CompileTagExpression() error = %v", err)
+	}
+
+	got := doc.FilterByTags(filter)
+	sc := got.Feature.Children[0].Scenario
+	if len(sc.Examples) != 1 || sc.Examples[0].Name != "fast" {
+		t.Errorf("sc.Examples = %+v, want only the %q block", sc.Examples, "fast")
+	}
+}
+
+func TestFilterByTagsNilDocument(t *testing.T) {
+	var doc *Document
+	filter := Atom{Tag: "@smoke"}
+	if got := doc.FilterByTags(filter); got != nil {
+		t.Errorf("FilterByTags() on nil Document = %+v, want nil", got)
+	}
+}
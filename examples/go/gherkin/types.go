@@ -8,70 +8,73 @@
 // are encapsulated â€” consumers interact with idiomatic Go types.
 package gherkin
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // Document is the top-level result of parsing a Gherkin source file.
 type Document struct {
-	Feature  *Feature
-	Comments []Comment
+	Feature  *Feature  `json:"feature,omitempty"`
+	Comments []Comment `json:"comments"`
 }
 
 // Feature represents a Gherkin Feature with its metadata and children.
 type Feature struct {
-	Location    Location
-	Tags        []Tag
-	Language    string
-	Keyword     string
-	Name        string
-	Description string
-	Children    []FeatureChild
+	Location    Location       `json:"location"`
+	Tags        []Tag          `json:"tags"`
+	Language    string         `json:"language"`
+	Keyword     string         `json:"keyword"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Children    []FeatureChild `json:"children"`
 }
 
 // FeatureChild is a child of a Feature: exactly one of Background, Scenario, or Rule is set.
 type FeatureChild struct {
-	Background *Background
-	Scenario   *Scenario
-	Rule       *Rule
+	Background *Background `json:"background,omitempty"`
+	Scenario   *Scenario   `json:"scenario,omitempty"`
+	Rule       *Rule       `json:"rule,omitempty"`
 }
 
 // Background represents a Background section with shared setup steps.
 type Background struct {
-	Location    Location
-	Keyword     string
-	Name        string
-	Description string
-	ID          string
-	Steps       []Step
+	Location    Location `json:"location"`
+	Keyword     string   `json:"keyword"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ID          string   `json:"id"`
+	Steps       []Step   `json:"steps"`
 }
 
 // Scenario represents a Scenario or Scenario Outline.
 type Scenario struct {
-	Location    Location
-	Tags        []Tag
-	Kind        ScenarioKind
-	Keyword     string
-	Name        string
-	Description string
-	ID          string
-	Steps       []Step
-	Examples    []Examples
+	Location    Location     `json:"location"`
+	Tags        []Tag        `json:"tags"`
+	Kind        ScenarioKind `json:"kind"`
+	Keyword     string       `json:"keyword"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	ID          string       `json:"id"`
+	Steps       []Step       `json:"steps"`
+	Examples    []Examples   `json:"examples"`
 }
 
 // Rule represents a business Rule grouping scenarios.
 type Rule struct {
-	Location    Location
-	Tags        []Tag
-	Keyword     string
-	Name        string
-	Description string
-	ID          string
-	Children    []RuleChild
+	Location    Location    `json:"location"`
+	Tags        []Tag       `json:"tags"`
+	Keyword     string      `json:"keyword"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	ID          string      `json:"id"`
+	Children    []RuleChild `json:"children"`
 }
 
 // RuleChild is a child of a Rule: either Background or Scenario.
 type RuleChild struct {
-	Background *Background
-	Scenario   *Scenario
+	Background *Background `json:"background,omitempty"`
+	Scenario   *Scenario   `json:"scenario,omitempty"`
 }
 
 // Step represents a single step (Given/When/Then/And/But).
@@ -84,6 +87,33 @@ type Step struct {
 	Argument    *StepArgument
 }
 
+// MarshalJSON flattens Argument so a Step serializes with "docString"/
+// "dataTable" directly on the step, matching the Cucumber Messages schema
+// (which has no intermediate "argument" wrapper).
+func (s Step) MarshalJSON() ([]byte, error) {
+	type wire struct {
+		Location    Location    `json:"location"`
+		Keyword     string      `json:"keyword"`
+		KeywordType KeywordType `json:"keywordType"`
+		Text        string      `json:"text"`
+		ID          string      `json:"id"`
+		DocString   *DocString  `json:"docString,omitempty"`
+		DataTable   *DataTable  `json:"dataTable,omitempty"`
+	}
+	w := wire{
+		Location:    s.Location,
+		Keyword:     s.Keyword,
+		KeywordType: s.KeywordType,
+		Text:        s.Text,
+		ID:          s.ID,
+	}
+	if s.Argument != nil {
+		w.DocString = s.Argument.DocString
+		w.DataTable = s.Argument.DataTable
+	}
+	return json.Marshal(w)
+}
+
 // StepArgument is either a DataTable or a DocString attached to a step.
 type StepArgument struct {
 	DataTable *DataTable
@@ -92,60 +122,60 @@ type StepArgument struct {
 
 // DataTable is a table argument attached to a step.
 type DataTable struct {
-	Location Location
-	Rows     []TableRow
+	Location Location   `json:"location"`
+	Rows     []TableRow `json:"rows"`
 }
 
 // DocString is a multi-line string argument attached to a step.
 type DocString struct {
-	Location  Location
-	MediaType string
-	Content   string
-	Delimiter string
+	Location  Location `json:"location"`
+	MediaType string   `json:"mediaType,omitempty"`
+	Content   string   `json:"content"`
+	Delimiter string   `json:"delimiter"`
 }
 
 // Examples represents an Examples section in a Scenario Outline.
 type Examples struct {
-	Location    Location
-	Tags        []Tag
-	Keyword     string
-	Name        string
-	Description string
-	ID          string
-	TableHeader *TableRow
-	TableBody   []TableRow
+	Location    Location  `json:"location"`
+	Tags        []Tag     `json:"tags"`
+	Keyword     string    `json:"keyword"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ID          string    `json:"id"`
+	TableHeader *TableRow  `json:"tableHeader,omitempty"`
+	TableBody   []TableRow `json:"tableBody"`
 }
 
 // TableRow is a row in a data table or examples table.
 type TableRow struct {
-	Location Location
-	ID       string
-	Cells    []TableCell
+	Location Location    `json:"location"`
+	ID       string      `json:"id"`
+	Cells    []TableCell `json:"cells"`
 }
 
 // TableCell is a single cell in a table row.
 type TableCell struct {
-	Location Location
-	Value    string
+	Location Location `json:"location"`
+	Value    string   `json:"value"`
 }
 
 // Tag represents a Gherkin tag (e.g., @smoke).
 type Tag struct {
-	Location Location
-	Name     string
-	ID       string
+	Location Location `json:"location"`
+	Name     string   `json:"name"`
+	ID       string   `json:"id"`
 }
 
 // Comment represents a comment line in the source.
 type Comment struct {
-	Location Location
-	Text     string
+	Location Location `json:"location"`
+	Text     string   `json:"text"`
 }
 
 // Location is a source position with line and optional column.
 type Location struct {
-	Line   int32
-	Column *int32
+	Line   int32  `json:"line"`
+	Column *int32 `json:"column,omitempty"`
 }
 
 // ScenarioKind distinguishes Scenario from Scenario Outline.
@@ -231,9 +261,9 @@ func (t TokenType) String() string {
 
 // ParseError is returned when the parser encounters invalid Gherkin syntax.
 type ParseError struct {
-	Message string
-	Line    int32
-	Column  *int32
+	Message string `json:"message"`
+	Line    int32  `json:"line"`
+	Column  *int32 `json:"column,omitempty"`
 }
 
 func (e *ParseError) Error() string {